@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"traktshow/backup"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runBackup 将账号的全部数据备份到一个版本化的压缩文件
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := fs.String("o", "", "备份文件路径（默认 traktshow-backup-日期时间.json.gz）")
+	fs.Parse(args)
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("traktshow-backup-%s.json.gz", time.Now().Format("20060102-150405"))
+	}
+
+	archive, err := backup.Create(accessToken)
+	if err != nil {
+		return err
+	}
+	if err := backup.Save(path, archive); err != nil {
+		return err
+	}
+	log.Printf("✅ 备份完成：%s（观看记录 %d 条，评分 %d 条，观看清单 %d 项，列表 %d 个）",
+		path, len(archive.History), len(archive.Ratings), len(archive.Watchlist), len(archive.Lists))
+	return nil
+}
+
+// runRestore 将备份回放到当前账号（可重复执行，中断后重新运行会跳过已完成的步骤）
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "仅统计将要恢复的数据，不实际提交")
+	restart := fs.Bool("restart", false, "忽略上次的恢复进度，从头开始")
+	only := fs.String("only", "", "只恢复指定步骤（逗号分隔）："+strings.Join(backup.RestoreSteps, "、"))
+	login := fs.Bool("login", false, "先重新授权（用于恢复到另一个账号，新令牌会覆盖已保存的令牌）")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("用法：restore [参数] <备份文件>")
+	}
+	path, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	archive, err := backup.Load(path)
+	if err != nil {
+		return err
+	}
+	log.Printf("备份版本 %d，创建于 %s", archive.Version, archive.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+
+	if *login {
+		accessToken = authorize()
+	}
+
+	// 加载上次的恢复进度（按备份文件与目标账号区分）
+	user, err := trakt.GetCurrentUser(accessToken)
+	if err != nil {
+		return err
+	}
+	log.Printf("恢复到账号：%s", user.Username)
+	sum := sha1.Sum([]byte(path + "|" + user.Username))
+	stateName := "restore_" + hex.EncodeToString(sum[:8])
+	completed := make(map[string]bool)
+	if !*restart {
+		utils.LoadCache(stateName, &completed)
+	}
+	var done []string
+	for _, step := range backup.RestoreSteps {
+		if completed[step] {
+			done = append(done, step)
+		}
+	}
+	if len(done) > 0 {
+		log.Printf("继续上次的恢复，跳过已完成的步骤：%s（使用 -restart 从头开始）", strings.Join(done, "、"))
+	}
+	// 未选中的步骤与已完成的步骤一样跳过，但不记入进度
+	skip := maps.Clone(completed)
+	if *only != "" {
+		selected := strings.Split(*only, ",")
+		for _, step := range backup.RestoreSteps {
+			if !slices.Contains(selected, step) {
+				skip[step] = true
+			}
+		}
+	}
+
+	err = backup.Restore(accessToken, archive, backup.RestoreOptions{
+		DryRun: *dryRun,
+		Done:   skip,
+		OnStepDone: func(step string) {
+			completed[step] = true
+			if err := utils.SaveCache(stateName, completed); err != nil {
+				log.Printf("⚠️  保存恢复进度失败：%v", err)
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%v（可重新运行以继续）", err)
+	}
+	// 全部步骤完成后清除进度，再次恢复时从头开始
+	if !*dryRun && !slices.ContainsFunc(backup.RestoreSteps, func(step string) bool { return !completed[step] }) {
+		if err := utils.DeleteCache(stateName); err != nil {
+			log.Printf("⚠️  清除恢复进度失败：%v", err)
+		}
+	}
+	log.Println("✅ 恢复完成（播放进度与评论无法通过接口恢复，已保留在备份文件中）")
+	return nil
+}
@@ -0,0 +1,90 @@
+package backup
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"traktshow/trakt"
+)
+
+// ArchiveVersion 当前备份格式版本（格式不兼容变更时递增）
+const ArchiveVersion = 1
+
+// HiddenSections 备份的隐藏分区
+var HiddenSections = []string{"calendar", "progress_watched", "progress_collected", "recommendations", "dropped"}
+
+// Archive 账号完整备份
+type Archive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Profile  json.RawMessage `json:"profile"`
+	Settings json.RawMessage `json:"settings"`
+
+	History   []trakt.TraktWatchHistoryItem `json:"history"`
+	Ratings   []trakt.TraktRating           `json:"ratings"`
+	Watchlist []trakt.TraktListItem         `json:"watchlist"`
+
+	Collection struct {
+		Movies []trakt.TraktCollectedMovie `json:"movies"`
+		Shows  []trakt.TraktCollectedShow  `json:"shows"`
+	} `json:"collection"`
+
+	Lists    []ListBackup                       `json:"lists"`
+	Hidden   map[string][]trakt.TraktHiddenItem `json:"hidden"`
+	Playback []trakt.TraktPlayback              `json:"playback"`
+	Comments []json.RawMessage                  `json:"comments"`
+}
+
+// ListBackup 个人列表及其条目
+type ListBackup struct {
+	List  trakt.TraktList       `json:"list"`
+	Items []trakt.TraktListItem `json:"items"`
+}
+
+// Save 将备份保存为 gzip 压缩的JSON文件
+func Save(path string, archive *Archive) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建备份文件失败：%v", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	encoder := json.NewEncoder(gz)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archive); err != nil {
+		return fmt.Errorf("写入备份失败：%v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("写入备份失败：%v", err)
+	}
+	return nil
+}
+
+// Load 读取备份文件（校验格式版本）
+func Load(path string) (*Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开备份文件失败：%v", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("读取备份失败：%v", err)
+	}
+	defer gz.Close()
+
+	var archive Archive
+	if err := json.NewDecoder(gz).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("解析备份失败：%v", err)
+	}
+	if archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("备份格式版本 %d 高于当前支持的版本 %d，请升级 traktshow", archive.Version, ArchiveVersion)
+	}
+	return &archive, nil
+}
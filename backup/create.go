@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"log"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+)
+
+// Create 获取账号的全部可访问数据并生成备份
+func Create(token *oauth2.Token) (*Archive, error) {
+	archive := &Archive{Version: ArchiveVersion, CreatedAt: time.Now(), Hidden: make(map[string][]trakt.TraktHiddenItem)}
+	var err error
+
+	log.Println("正在备份用户资料与设置...")
+	if archive.Profile, err = trakt.GetUserProfile(token); err != nil {
+		return nil, err
+	}
+	if archive.Settings, err = trakt.GetUserSettings(token); err != nil {
+		return nil, err
+	}
+
+	log.Println("正在备份观看记录...")
+	if archive.History, err = trakt.GetHistoryRange(token, "", time.Time{}, time.Time{}); err != nil {
+		return nil, err
+	}
+
+	log.Println("正在备份评分与观看清单...")
+	if archive.Ratings, err = trakt.GetRatings(token, ""); err != nil {
+		return nil, err
+	}
+	if archive.Watchlist, err = trakt.GetWatchlist(token, "", "rank"); err != nil {
+		return nil, err
+	}
+
+	log.Println("正在备份收藏...")
+	if archive.Collection.Movies, err = trakt.GetCollectedMovies(token); err != nil {
+		return nil, err
+	}
+	if archive.Collection.Shows, err = trakt.GetCollectedShows(token); err != nil {
+		return nil, err
+	}
+
+	log.Println("正在备份个人列表...")
+	lists, err := trakt.GetLists(token, "me")
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		items, err := trakt.GetListItems(token, "me", list.IDs.Slug)
+		if err != nil {
+			return nil, err
+		}
+		archive.Lists = append(archive.Lists, ListBackup{List: list, Items: items})
+	}
+
+	log.Println("正在备份隐藏条目、播放进度与评论...")
+	for _, section := range HiddenSections {
+		if archive.Hidden[section], err = trakt.GetHiddenItems(token, section, ""); err != nil {
+			return nil, err
+		}
+	}
+	if archive.Playback, err = trakt.GetPlayback(token); err != nil {
+		return nil, err
+	}
+	if archive.Comments, err = trakt.GetComments(token); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+
+	"traktshow/trakt"
+)
+
+// Diff 两份备份之间的差异
+type Diff struct {
+	OldCreatedAt string `json:"old_created_at"`
+	NewCreatedAt string `json:"new_created_at"`
+
+	AddedPlays   []trakt.TraktWatchHistoryItem `json:"added_plays"`
+	RemovedPlays []trakt.TraktWatchHistoryItem `json:"removed_plays"`
+
+	RatingChanges []RatingChange `json:"rating_changes"`
+
+	WatchlistAdded   []trakt.TraktListItem `json:"watchlist_added"`
+	WatchlistRemoved []trakt.TraktListItem `json:"watchlist_removed"`
+
+	ListChanges []ListChange `json:"list_changes"`
+}
+
+// RatingChange 评分变化（Old 为 nil 表示新增评分，New 为 nil 表示删除评分）
+type RatingChange struct {
+	Old *trakt.TraktRating `json:"old,omitempty"`
+	New *trakt.TraktRating `json:"new,omitempty"`
+}
+
+// ListChange 个人列表的变化
+type ListChange struct {
+	Name         string                `json:"name"`
+	Created      bool                  `json:"created,omitempty"`
+	Deleted      bool                  `json:"deleted,omitempty"`
+	RenamedFrom  string                `json:"renamed_from,omitempty"`
+	ItemsAdded   []trakt.TraktListItem `json:"items_added,omitempty"`
+	ItemsRemoved []trakt.TraktListItem `json:"items_removed,omitempty"`
+}
+
+// Empty 是否没有任何差异
+func (d *Diff) Empty() bool {
+	return len(d.AddedPlays) == 0 && len(d.RemovedPlays) == 0 && len(d.RatingChanges) == 0 &&
+		len(d.WatchlistAdded) == 0 && len(d.WatchlistRemoved) == 0 && len(d.ListChanges) == 0
+}
+
+// Compare 比较两份备份（old 为较早的备份）
+func Compare(old, new *Archive) *Diff {
+	diff := &Diff{
+		OldCreatedAt: old.CreatedAt.Format("2006-01-02 15:04:05"),
+		NewCreatedAt: new.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	// 观看记录：按条目与观看时间比较（同一时间的多次播放分别计数）
+	diff.AddedPlays, diff.RemovedPlays = diffByKey(old.History, new.History, playKey)
+
+	// 评分
+	oldRatings := make(map[string]*trakt.TraktRating)
+	for i := range old.Ratings {
+		oldRatings[ratingItemKey(&old.Ratings[i])] = &old.Ratings[i]
+	}
+	seen := make(map[string]bool)
+	for i := range new.Ratings {
+		r := &new.Ratings[i]
+		key := ratingItemKey(r)
+		seen[key] = true
+		if prev, ok := oldRatings[key]; !ok {
+			diff.RatingChanges = append(diff.RatingChanges, RatingChange{New: r})
+		} else if prev.Rating != r.Rating {
+			diff.RatingChanges = append(diff.RatingChanges, RatingChange{Old: prev, New: r})
+		}
+	}
+	for i := range old.Ratings {
+		if !seen[ratingItemKey(&old.Ratings[i])] {
+			diff.RatingChanges = append(diff.RatingChanges, RatingChange{Old: &old.Ratings[i]})
+		}
+	}
+
+	// 观看清单
+	diff.WatchlistAdded, diff.WatchlistRemoved = diffByKey(old.Watchlist, new.Watchlist, listItemKey)
+
+	// 个人列表：按列表 Trakt ID 匹配（可识别重命名）
+	oldLists := make(map[int]*ListBackup)
+	for i := range old.Lists {
+		oldLists[old.Lists[i].List.IDs.Trakt] = &old.Lists[i]
+	}
+	seenLists := make(map[int]bool)
+	for i := range new.Lists {
+		cur := &new.Lists[i]
+		seenLists[cur.List.IDs.Trakt] = true
+		change := ListChange{Name: cur.List.Name}
+		prev, ok := oldLists[cur.List.IDs.Trakt]
+		if !ok {
+			change.Created = true
+			change.ItemsAdded = cur.Items
+			diff.ListChanges = append(diff.ListChanges, change)
+			continue
+		}
+		if prev.List.Name != cur.List.Name {
+			change.RenamedFrom = prev.List.Name
+		}
+		change.ItemsAdded, change.ItemsRemoved = diffByKey(prev.Items, cur.Items, listItemKey)
+		if change.RenamedFrom != "" || len(change.ItemsAdded) > 0 || len(change.ItemsRemoved) > 0 {
+			diff.ListChanges = append(diff.ListChanges, change)
+		}
+	}
+	for i := range old.Lists {
+		if !seenLists[old.Lists[i].List.IDs.Trakt] {
+			diff.ListChanges = append(diff.ListChanges, ListChange{Name: old.Lists[i].List.Name, Deleted: true, ItemsRemoved: old.Lists[i].Items})
+		}
+	}
+
+	sort.SliceStable(diff.AddedPlays, func(i, j int) bool {
+		return diff.AddedPlays[i].WatchedAt.Before(diff.AddedPlays[j].WatchedAt)
+	})
+	sort.SliceStable(diff.RemovedPlays, func(i, j int) bool {
+		return diff.RemovedPlays[i].WatchedAt.Before(diff.RemovedPlays[j].WatchedAt)
+	})
+	return diff
+}
+
+// diffByKey 按键比较两个列表，返回新增与删除的元素（相同键出现多次时按次数比较）
+func diffByKey[T any](old, new []T, key func(*T) string) (added, removed []T) {
+	counts := make(map[string]int)
+	for i := range old {
+		counts[key(&old[i])]++
+	}
+	for i := range new {
+		k := key(&new[i])
+		if counts[k] > 0 {
+			counts[k]--
+			continue
+		}
+		added = append(added, new[i])
+	}
+	// 剩余计数即为被删除的元素
+	for i := range old {
+		k := key(&old[i])
+		if counts[k] > 0 {
+			counts[k]--
+			removed = append(removed, old[i])
+		}
+	}
+	return added, removed
+}
+
+// ratingItemKey 评分条目的键（类型 + Trakt ID）
+func ratingItemKey(r *trakt.TraktRating) string {
+	return fmt.Sprintf("%s:%d", r.Type, r.TraktID())
+}
+
+// listItemKey 列表条目的键（类型 + 条目的 Trakt ID）
+func listItemKey(item *trakt.TraktListItem) string {
+	var id int
+	switch {
+	case item.Movie != nil && item.Type == "movie":
+		id = item.Movie.IDs.Trakt
+	case item.Episode != nil && item.Type == "episode":
+		id = item.Episode.IDs.Trakt
+	case item.Season != nil && item.Type == "season":
+		id = item.Season.IDs.Trakt
+	case item.Show != nil:
+		id = item.Show.IDs.Trakt
+	}
+	return fmt.Sprintf("%s:%d", item.Type, id)
+}
@@ -0,0 +1,297 @@
+package backup
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+)
+
+// RestoreSteps 恢复的步骤（按顺序执行）
+var RestoreSteps = []string{"history", "ratings", "watchlist", "collection", "lists", "hidden"}
+
+// 每批提交的条目数
+const restoreBatchSize = 500
+
+// RestoreOptions 恢复选项
+type RestoreOptions struct {
+	DryRun     bool              // 仅统计，不实际提交
+	Done       map[string]bool   // 已完成的步骤（断点续传时跳过）
+	OnStepDone func(step string) // 每个步骤完成后的回调（用于保存进度）
+}
+
+// Restore 将备份回放到当前令牌对应的账号（可重复执行：已存在的观看记录不会重复添加，其余数据由接口去重）
+func Restore(token *oauth2.Token, archive *Archive, opts RestoreOptions) error {
+	steps := map[string]func(*oauth2.Token, *Archive, bool) error{
+		"history":    restoreHistory,
+		"ratings":    restoreRatings,
+		"watchlist":  restoreWatchlist,
+		"collection": restoreCollection,
+		"lists":      restoreLists,
+		"hidden":     restoreHidden,
+	}
+
+	for _, step := range RestoreSteps {
+		if opts.Done[step] {
+			log.Printf("跳过步骤：%s", step)
+			continue
+		}
+		log.Printf("正在恢复：%s", step)
+		if err := steps[step](token, archive, opts.DryRun); err != nil {
+			return fmt.Errorf("恢复 %s 失败：%v", step, err)
+		}
+		if !opts.DryRun && opts.OnStepDone != nil {
+			opts.OnStepDone(step)
+		}
+	}
+	return nil
+}
+
+// restoreHistory 恢复观看记录（跳过目标账号中已存在的同一条目同一时间的播放）
+func restoreHistory(token *oauth2.Token, archive *Archive, dryRun bool) error {
+	existing := make(map[string]bool)
+	if !dryRun {
+		current, err := trakt.GetHistoryRange(token, "", time.Time{}, time.Time{})
+		if err != nil {
+			return err
+		}
+		for i := range current {
+			existing[playKey(&current[i])] = true
+		}
+	}
+
+	var items trakt.SyncItems
+	for i := range archive.History {
+		play := &archive.History[i]
+		if existing[playKey(play)] {
+			continue
+		}
+		existing[playKey(play)] = true
+		watchedAt := play.WatchedAt.UTC().Format(time.RFC3339)
+		switch {
+		case play.Movie != nil:
+			items.Movies = append(items.Movies, trakt.SyncItem{IDs: play.Movie.IDs, WatchedAt: watchedAt})
+		case play.Episode != nil:
+			items.Episodes = append(items.Episodes, trakt.SyncItem{IDs: play.Episode.IDs, WatchedAt: watchedAt})
+		}
+	}
+	return submitBatches(items, dryRun, func(batch trakt.SyncItems) (*trakt.SyncResult, error) {
+		return trakt.AddToHistory(token, batch)
+	})
+}
+
+// restoreRatings 恢复评分（保留原评分时间）
+func restoreRatings(token *oauth2.Token, archive *Archive, dryRun bool) error {
+	var items trakt.SyncItems
+	for _, r := range archive.Ratings {
+		item := trakt.SyncItem{Rating: r.Rating, RatedAt: r.RatedAt.UTC().Format(time.RFC3339)}
+		switch {
+		case r.Type == "movie" && r.Movie != nil:
+			item.IDs = r.Movie.IDs
+			items.Movies = append(items.Movies, item)
+		case r.Type == "show" && r.Show != nil:
+			item.IDs = r.Show.IDs
+			items.Shows = append(items.Shows, item)
+		case r.Type == "season" && r.Season != nil:
+			item.IDs = r.Season.IDs
+			items.Seasons = append(items.Seasons, item)
+		case r.Type == "episode" && r.Episode != nil:
+			item.IDs = r.Episode.IDs
+			items.Episodes = append(items.Episodes, item)
+		}
+	}
+	return submitBatches(items, dryRun, func(batch trakt.SyncItems) (*trakt.SyncResult, error) {
+		return trakt.AddRatings(token, batch)
+	})
+}
+
+// restoreWatchlist 恢复观看清单
+func restoreWatchlist(token *oauth2.Token, archive *Archive, dryRun bool) error {
+	items := listItemsToSync(archive.Watchlist)
+	return submitBatches(items, dryRun, func(batch trakt.SyncItems) (*trakt.SyncResult, error) {
+		return trakt.AddToWatchlist(token, batch)
+	})
+}
+
+// restoreCollection 恢复收藏（保留收藏时间与媒体信息）
+func restoreCollection(token *oauth2.Token, archive *Archive, dryRun bool) error {
+	var items trakt.SyncItems
+	for _, m := range archive.Collection.Movies {
+		meta := m.Metadata
+		meta.CollectedAt = m.CollectedAt.UTC().Format(time.RFC3339)
+		items.Movies = append(items.Movies, trakt.SyncItem{IDs: m.Movie.IDs, MediaMetadata: meta})
+	}
+	for _, s := range archive.Collection.Shows {
+		show := trakt.SyncItem{IDs: s.Show.IDs}
+		for _, season := range s.Seasons {
+			syncSeason := trakt.SyncSeason{Number: season.Number}
+			for _, e := range season.Episodes {
+				meta := e.Metadata
+				meta.CollectedAt = e.CollectedAt.UTC().Format(time.RFC3339)
+				syncSeason.Episodes = append(syncSeason.Episodes, trakt.SyncEpisode{Number: e.Number, MediaMetadata: meta})
+			}
+			show.Seasons = append(show.Seasons, syncSeason)
+		}
+		items.Shows = append(items.Shows, show)
+	}
+	return submitBatches(items, dryRun, func(batch trakt.SyncItems) (*trakt.SyncResult, error) {
+		return trakt.AddToCollection(token, batch)
+	})
+}
+
+// restoreLists 恢复个人列表（按名称匹配目标账号中的列表，不存在时创建）
+func restoreLists(token *oauth2.Token, archive *Archive, dryRun bool) error {
+	existing := make(map[string]string)
+	if !dryRun {
+		lists, err := trakt.GetLists(token, "me")
+		if err != nil {
+			return err
+		}
+		for _, list := range lists {
+			existing[list.Name] = list.IDs.Slug
+		}
+	}
+
+	for _, backup := range archive.Lists {
+		list := backup.List
+		items := listItemsToSync(backup.Items)
+		if dryRun {
+			log.Printf("[预览] 列表「%s」：%d 项", list.Name, items.Len())
+			continue
+		}
+
+		slug, ok := existing[list.Name]
+		if !ok {
+			created, err := trakt.CreateList(token, trakt.ListSettings{
+				Name:           &list.Name,
+				Description:    &list.Description,
+				Privacy:        &list.Privacy,
+				DisplayNumbers: &list.DisplayNumbers,
+				AllowComments:  &list.AllowComments,
+				SortBy:         &list.SortBy,
+				SortHow:        &list.SortHow,
+			})
+			if err != nil {
+				return err
+			}
+			slug = created.IDs.Slug
+		}
+		if items.Len() == 0 {
+			continue
+		}
+		err := submitBatches(items, false, func(batch trakt.SyncItems) (*trakt.SyncResult, error) {
+			return trakt.AddListItems(token, slug, batch)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreHidden 恢复各分区的隐藏条目
+func restoreHidden(token *oauth2.Token, archive *Archive, dryRun bool) error {
+	for _, section := range HiddenSections {
+		var items trakt.SyncItems
+		for _, h := range archive.Hidden[section] {
+			switch {
+			case h.Movie != nil:
+				items.Movies = append(items.Movies, trakt.SyncItem{IDs: h.Movie.IDs})
+			case h.Season != nil:
+				items.Seasons = append(items.Seasons, trakt.SyncItem{IDs: h.Season.IDs})
+			case h.Show != nil:
+				items.Shows = append(items.Shows, trakt.SyncItem{IDs: h.Show.IDs})
+			}
+		}
+		if items.Len() == 0 {
+			continue
+		}
+		err := submitBatches(items, dryRun, func(batch trakt.SyncItems) (*trakt.SyncResult, error) {
+			return trakt.AddHiddenItems(token, section, batch)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listItemsToSync 将列表条目转换为 sync 请求条目
+func listItemsToSync(list []trakt.TraktListItem) trakt.SyncItems {
+	var items trakt.SyncItems
+	for _, item := range list {
+		switch {
+		case item.Type == "movie" && item.Movie != nil:
+			items.Movies = append(items.Movies, trakt.SyncItem{IDs: item.Movie.IDs})
+		case item.Type == "show" && item.Show != nil:
+			items.Shows = append(items.Shows, trakt.SyncItem{IDs: item.Show.IDs})
+		case item.Type == "season" && item.Season != nil:
+			items.Seasons = append(items.Seasons, trakt.SyncItem{IDs: item.Season.IDs})
+		case item.Type == "episode" && item.Episode != nil:
+			items.Episodes = append(items.Episodes, trakt.SyncItem{IDs: item.Episode.IDs})
+		}
+	}
+	return items
+}
+
+// submitBatches 分批提交条目（dryRun 时只打印数量）
+func submitBatches(items trakt.SyncItems, dryRun bool, submit func(trakt.SyncItems) (*trakt.SyncResult, error)) error {
+	if dryRun {
+		log.Printf("[预览] 共 %d 项（未实际提交）", items.Len())
+		return nil
+	}
+
+	for _, batch := range splitBatches(items, restoreBatchSize) {
+		result, err := submit(batch)
+		if err != nil {
+			return err
+		}
+		if n := result.NotFound.Len(); n > 0 {
+			log.Printf("⚠️  %d 项在 Trakt 中未找到", n)
+		}
+	}
+	return nil
+}
+
+// splitBatches 按条目数拆分为多批
+func splitBatches(items trakt.SyncItems, size int) []trakt.SyncItems {
+	var batches []trakt.SyncItems
+	var current trakt.SyncItems
+	flush := func() {
+		if current.Len() > 0 {
+			batches = append(batches, current)
+			current = trakt.SyncItems{}
+		}
+	}
+	for _, list := range []struct {
+		items []trakt.SyncItem
+		add   func(trakt.SyncItem)
+	}{
+		{items.Movies, func(i trakt.SyncItem) { current.Movies = append(current.Movies, i) }},
+		{items.Shows, func(i trakt.SyncItem) { current.Shows = append(current.Shows, i) }},
+		{items.Seasons, func(i trakt.SyncItem) { current.Seasons = append(current.Seasons, i) }},
+		{items.Episodes, func(i trakt.SyncItem) { current.Episodes = append(current.Episodes, i) }},
+	} {
+		for _, item := range list.items {
+			list.add(item)
+			if current.Len() >= size {
+				flush()
+			}
+		}
+	}
+	flush()
+	return batches
+}
+
+// playKey 观看记录的去重键（条目类型 + Trakt ID + 观看时间）
+func playKey(play *trakt.TraktWatchHistoryItem) string {
+	switch {
+	case play.Movie != nil:
+		return fmt.Sprintf("movie:%d@%d", play.Movie.IDs.Trakt, play.WatchedAt.Unix())
+	case play.Episode != nil:
+		return fmt.Sprintf("episode:%d@%d", play.Episode.IDs.Trakt, play.WatchedAt.Unix())
+	}
+	return fmt.Sprintf("history:%d", play.ID)
+}
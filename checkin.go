@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runCheckin 签到正在观看的电影或单集（checkin cancel 取消当前签到）
+func runCheckin(args []string) error {
+	if len(args) > 0 && args[0] == "cancel" {
+		if err := trakt.DeleteCheckin(accessToken); err != nil {
+			return err
+		}
+		fmt.Println("✅ 已取消当前签到")
+		return nil
+	}
+
+	fs := flag.NewFlagSet("checkin", flag.ExitOnError)
+	target := addItemFlags(fs)
+	message := fs.String("message", "", "签到附言（同步到已连接的社交账号）")
+	replace := fs.Bool("replace", false, "已有签到时直接取消并替换（不再询问）")
+	fs.Parse(args)
+
+	if len(fs.Args()) != 1 {
+		return fmt.Errorf("请指定一个条目")
+	}
+	scrobbleReq, err := newScrobbleRequest(*target.kind, fs.Arg(0), *target.season, *target.episode)
+	if err != nil {
+		return err
+	}
+	req := trakt.CheckinRequest{
+		Movie:   scrobbleReq.Movie,
+		Show:    scrobbleReq.Show,
+		Episode: scrobbleReq.Episode,
+		Message: *message,
+	}
+
+	result, err := trakt.Checkin(accessToken, req)
+	var conflict *trakt.CheckinConflictError
+	if errors.As(err, &conflict) {
+		fmt.Printf("⚠️  %v\n", conflict)
+		if watching, err := trakt.GetWatching(accessToken); err == nil && watching != nil {
+			fmt.Printf("当前签到：%s\n", utils.FormatMediaTitle(watching.Movie, watching.Show, nil, watching.Episode))
+		}
+		if !*replace && !confirm("是否取消当前签到并替换？(y/N)：") {
+			return nil
+		}
+		if err := trakt.DeleteCheckin(accessToken); err != nil {
+			return err
+		}
+		result, err = trakt.Checkin(accessToken, req)
+	}
+	if err != nil {
+		return err
+	}
+	utils.PrintCheckinResult(result)
+	return nil
+}
+
+// confirm 提示用户确认（输入 y/yes 时返回 true）
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"traktshow/library"
+	"traktshow/release"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// scanMatch 文件识别结果（缓存在本地，文件大小与修改时间不变时不再重新识别）
+type scanMatch struct {
+	Size       int64          `json:"size"`
+	ModTime    time.Time      `json:"mod_time"`
+	Entry      *library.Entry `json:"entry,omitempty"` // 无法识别时为 nil
+	Confidence float64        `json:"confidence"`
+	Probed     bool           `json:"probed"` // Entry.Metadata 是否为读取到的媒体信息（与 -probe 不一致时重新读取）
+	Error      string         `json:"error,omitempty"`
+}
+
+// runCollection 收藏管理（list/add/remove/report/scan）
+func runCollection(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "report":
+		movies, err := trakt.GetCollectedMovies(accessToken)
+		if err != nil {
+			return err
+		}
+		shows, err := trakt.GetCollectedShows(accessToken)
+		if err != nil {
+			return err
+		}
+		if args[0] == "list" {
+			utils.PrintCollection(movies, shows)
+		} else {
+			utils.PrintCollectionQualityReport(movies, shows)
+		}
+		return nil
+
+	case "add", "remove":
+		fs := flag.NewFlagSet("collection "+args[0], flag.ExitOnError)
+		target := addItemFlags(fs)
+		var meta trakt.MediaMetadata
+		at := fs.String("at", "now", "收藏时间：now、released 或具体时间（仅 add）")
+		fs.StringVar(&meta.MediaType, "media-type", "", "媒体类型：digital、bluray、dvd 等（仅 add）")
+		fs.StringVar(&meta.Resolution, "resolution", "", "分辨率：uhd_4k、hd_1080p、hd_720p、sd_480p 等（仅 add）")
+		fs.StringVar(&meta.HDR, "hdr", "", "HDR：dolby_vision、hdr10、hdr10_plus、hlg（仅 add）")
+		fs.StringVar(&meta.Audio, "audio", "", "音频：dolby_truehd、dolby_atmos、dts_x、aac 等（仅 add）")
+		fs.StringVar(&meta.AudioChannels, "channels", "", "声道：2.0、5.1、7.1 等（仅 add）")
+		fs.BoolVar(&meta.ThreeD, "3d", false, "是否为3D（仅 add）")
+		fs.Parse(args[1:])
+
+		items, err := target.resolve(fs.Args())
+		if err != nil {
+			return err
+		}
+
+		var result *trakt.SyncResult
+		if args[0] == "add" {
+			if meta.CollectedAt, err = parseWatchedAt(*at); err != nil {
+				return err
+			}
+			items.SetMetadata(meta)
+			result, err = trakt.AddToCollection(accessToken, items)
+		} else {
+			result, err = trakt.RemoveFromCollection(accessToken, items)
+		}
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+		return nil
+
+	case "scan":
+		return scanCollection(args[1:])
+	}
+	return fmt.Errorf("未知的子命令：%s（可用：list、add、remove、report、scan）", args[0])
+}
+
+// scanCollection 扫描本地媒体目录并同步到收藏（添加新条目，-remove 时移除本地不存在的条目）
+func scanCollection(args []string) error {
+	fs := flag.NewFlagSet("collection scan", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "仅打印比较结果，不实际提交")
+	remove := fs.Bool("remove", false, "从收藏中移除本地目录中不存在的条目（请确保扫描的是完整的媒体库）")
+	minConfidence := fs.Float64("min-confidence", 0.7, "文件名匹配的最低置信度（0-1，NFO 中有ID的文件不受影响）")
+	rescan := fs.Bool("rescan", false, "忽略缓存的识别结果，重新识别所有文件")
+	probe := fs.Bool("probe", true, "读取分辨率、HDR 与音频信息（已安装 ffprobe 时读取容器信息，否则根据文件名推断）")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("用法：collection scan [参数] <媒体目录>")
+	}
+	root, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	files, err := library.Scan(root)
+	if err != nil {
+		return fmt.Errorf("扫描目录失败：%v", err)
+	}
+	log.Printf("共找到 %d 个视频文件", len(files))
+
+	// 1. 识别文件（复用缓存的识别结果）
+	cache := make(map[string]scanMatch)
+	if !*rescan {
+		utils.LoadCache("library_matches", &cache)
+	}
+	matcher := release.NewMatcher(accessToken, nil)
+	var entries []library.Entry
+	var unidentified []string
+	for i := range files {
+		file := &files[i]
+		cached, ok := cache[file.Path]
+		if !ok || cached.Size != file.Size || !cached.ModTime.Equal(file.ModTime) {
+			cached = scanMatch{Size: file.Size, ModTime: file.ModTime}
+			entry, confidence, err := library.Identify(file, matcher)
+			cached.Entry, cached.Confidence = entry, confidence
+			switch {
+			case err == nil:
+				cache[file.Path] = cached
+			case errors.Is(err, release.ErrNoMatch):
+				cached.Error = err.Error()
+				cache[file.Path] = cached
+			default:
+				// 请求失败（限流、超时等）不缓存，下次扫描时重试
+				cached.Error = err.Error()
+				delete(cache, file.Path)
+			}
+			if (i+1)%50 == 0 {
+				log.Printf("已识别 %d/%d 个文件", i+1, len(files))
+				utils.SaveCache("library_matches", cache)
+			}
+		}
+
+		// 媒体信息与 -probe 不一致时重新读取或清除
+		if cached.Entry != nil && cached.Probed != *probe {
+			cached.Entry.Metadata = trakt.MediaMetadata{}
+			if *probe {
+				cached.Entry.Metadata = library.Probe(file.Path)
+			}
+			cached.Probed = *probe
+			cache[file.Path] = cached
+		}
+
+		switch {
+		case cached.Entry == nil:
+			unidentified = append(unidentified, fmt.Sprintf("%s（%s）", file.Path, cached.Error))
+		case cached.Confidence < *minConfidence:
+			unidentified = append(unidentified, fmt.Sprintf("%s（置信度 %.0f%%：%s）", file.Path, cached.Confidence*100, cached.Entry.Title))
+		default:
+			entry := *cached.Entry
+			entry.Metadata.CollectedAt = file.ModTime.UTC().Format(time.RFC3339)
+			entries = append(entries, entry)
+		}
+	}
+	if err := utils.SaveCache("library_matches", cache); err != nil {
+		log.Printf("⚠️  保存识别结果失败：%v", err)
+	}
+
+	// 2. 与收藏比较
+	movies, err := trakt.GetCollectedMovies(accessToken)
+	if err != nil {
+		return err
+	}
+	shows, err := trakt.GetCollectedShows(accessToken)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Title != entries[j].Title {
+			return entries[i].Title < entries[j].Title
+		}
+		if entries[i].Season != entries[j].Season {
+			return entries[i].Season < entries[j].Season
+		}
+		return entries[i].Episode < entries[j].Episode
+	})
+	added, missing := library.Diff(entries, movies, shows)
+	if *remove && len(unidentified) > 0 {
+		// 无法识别的文件可能对应收藏中的条目，此时移除会误删本地存在的条目
+		fmt.Printf("⚠️  有 %d 个文件无法识别，本次不移除任何条目（处理后重新扫描，或调整 -min-confidence）\n", len(unidentified))
+		*remove = false
+	}
+	utils.PrintScanReport(added, missing, unidentified, *remove)
+
+	if *dryRun {
+		fmt.Println("[预览] 未实际提交")
+		return nil
+	}
+
+	// 3. 提交
+	if len(added) > 0 {
+		result, err := trakt.AddToCollection(accessToken, library.SyncItems(added))
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+	}
+	if *remove && len(missing) > 0 {
+		result, err := trakt.RemoveFromCollection(accessToken, library.SyncItems(missing))
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+	}
+	return nil
+}
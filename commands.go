@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+)
+
+// command 子命令定义
+type command struct {
+	usage string                    // 命令说明（显示在帮助信息中）
+	run   func(args []string) error // 命令执行函数（args 为命令名之后的参数）
+}
+
+// commands 所有已注册的子命令
+var commands = map[string]command{
+	"backup":     {usage: "将账号全部数据备份到版本化的压缩文件（-o 指定路径）", run: runBackup},
+	"checkin":    {usage: "签到正在观看的电影或单集（checkin cancel 取消签到）", run: runCheckin},
+	"collection": {usage: "收藏管理（list、add、remove、report 按画质分组，scan 同步本地媒体目录）", run: runCollection},
+	"diff":       {usage: "比较两份备份或本地镜像与当前账号的变化（-json，-update-mirror）", run: runDiff},
+	"discover":   {usage: "浏览发现榜单（trending、popular、anticipated、watched、played、collected、boxoffice）", run: runDiscover},
+	"export":     {usage: "导出电影观看记录与评分为 Letterboxd 导入CSV（-o 输出文件）", run: runExport},
+	"feed":       {usage: "将最近的观看记录生成为 RSS/Atom 订阅源（-rss、-atom 指定输出文件）", run: runFeed},
+	"history":    {usage: "添加或删除观看记录（add、remove，支持 -dry-run 预览）", run: runHistory},
+	"import":     {usage: "从 IMDb、Letterboxd、TV Time 导出文件导入（-dry-run 预览，支持断点续传）", run: runImport},
+	"lists":      {usage: "个人列表管理（list、liked、show、create、update、delete、add、remove）", run: runLists},
+	"ratings":    {usage: "评分管理与分析（list、set、remove、stats、deviation）", run: runRatings},
+	"recommend":  {usage: "查看个性化推荐（-pick 加入观看清单或隐藏，hide 隐藏推荐）", run: runRecommend},
+	"restore":    {usage: "将备份恢复到当前账号（可重复执行，支持断点续传与 -dry-run）", run: runRestore},
+	"scrobble":   {usage: "上报播放状态（start、pause、stop，-progress 指定进度）", run: runScrobble},
+	"serve":      {usage: "以服务模式运行（plex、jellyfin、emby 接收媒体服务器通知并自动上报播放状态，web 观影仪表盘与订阅源，metrics Prometheus 指标）", run: runServe},
+	"search":     {usage: "搜索电影、剧集、单集和人物（-type、-year、-fields，-id 按ID查找）", run: runSearch},
+	"suggest":    {usage: "基于本地口味画像的推荐（附推荐理由，-offline 仅用缓存）", run: runSuggest},
+	"tui":        {usage: "全屏终端界面（观看记录、追剧进度、日历、观看清单、统计，支持评分与标记已看）", run: runTUI},
+	"up-next":    {usage: "查看追剧进度与下一集（-sort last-watched|air-date，-hidden，-dropped）", run: runUpNext},
+	"watch":      {usage: "监听本地播放器并自动上报播放状态（mpv，按文件名识别条目）", run: runWatch},
+	"watchlist":  {usage: "管理观看清单（list、add、remove、reorder）", run: runWatchlist},
+}
+
+// runCommand 执行指定子命令，未知命令时打印帮助信息
+func runCommand(name string, args []string) {
+	cmd, ok := commands[name]
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
+	if err := cmd.run(args); err != nil {
+		log.Fatalf("❌ %s 执行失败：%v", name, err)
+	}
+}
+
+// printUsage 打印命令帮助信息
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("用法：traktshow [命令] [参数]")
+	fmt.Println("不带命令时查询并打印用户信息与最近观看记录。")
+	fmt.Println("\n可用命令：")
+	for _, name := range names {
+		fmt.Printf("  %-14s %s\n", name, commands[name].usage)
+	}
+	fmt.Println("\n使用 traktshow <命令> -h 查看命令参数。")
+}
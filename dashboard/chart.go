@@ -0,0 +1,76 @@
+package dashboard
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// Bar 柱状图中的一根柱子（Values 为自下而上堆叠的各部分）
+type Bar struct {
+	Label   string
+	Values  []float64
+	Tooltip string
+}
+
+// BarChart 生成堆叠柱状图的 SVG（colors 与 Values 的顺序一一对应；labelEvery 控制横轴标签的间隔）
+func BarChart(bars []Bar, colors []string, width, height, labelEvery int) template.HTML {
+	const top, bottom, left = 10, 24, 28
+	plotHeight := float64(height - top - bottom)
+	plotWidth := float64(width - left)
+
+	maxValue := 0.0
+	for _, b := range bars {
+		total := 0.0
+		for _, v := range b.Values {
+			total += v
+		}
+		maxValue = math.Max(maxValue, total)
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="chart">`, width, height)
+	// 纵轴刻度（0、一半、最大值）
+	for _, v := range []float64{0, maxValue / 2, maxValue} {
+		y := float64(top) + plotHeight - v/maxValue*plotHeight
+		fmt.Fprintf(&svg, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grid"/>`, left, y, width, y)
+		fmt.Fprintf(&svg, `<text x="%d" y="%.1f" class="axis" text-anchor="end">%s</text>`, left-4, y+4, formatValue(v))
+	}
+
+	if len(bars) > 0 {
+		slot := plotWidth / float64(len(bars))
+		barWidth := math.Max(slot*0.7, 1)
+		for i, b := range bars {
+			x := float64(left) + float64(i)*slot + (slot-barWidth)/2
+			y := float64(top) + plotHeight
+			fmt.Fprintf(&svg, `<g><title>%s</title>`, template.HTMLEscapeString(b.Tooltip))
+			for j, v := range b.Values {
+				if v <= 0 {
+					continue
+				}
+				h := v / maxValue * plotHeight
+				y -= h
+				fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, x, y, barWidth, h, colors[j%len(colors)])
+			}
+			svg.WriteString(`</g>`)
+			if labelEvery <= 1 || i%labelEvery == 0 {
+				fmt.Fprintf(&svg, `<text x="%.1f" y="%d" class="axis" text-anchor="middle">%s</text>`,
+					x+barWidth/2, height-6, template.HTMLEscapeString(b.Label))
+			}
+		}
+	}
+	svg.WriteString(`</svg>`)
+	return template.HTML(svg.String())
+}
+
+// formatValue 刻度值（整数不显示小数）
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
@@ -0,0 +1,131 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// 近期观看记录的条数与每日播放统计的天数
+const (
+	recentHistoryLimit = 30
+	dailyDays          = 30
+	calendarDays       = 14
+)
+
+// Data 仪表盘展示的数据
+type Data struct {
+	Profile   trakt.TraktUserInfo
+	Stats     trakt.TraktUserStats
+	History   []trakt.TraktWatchHistoryItem // 最近的观看记录
+	Daily     []DailyPlays                  // 近30天每日播放次数（按日期从早到晚）
+	UpNext    []trakt.UpNextItem
+	Calendar  []trakt.TraktCalendarEpisode
+	UpdatedAt time.Time
+}
+
+// DailyPlays 单日的播放次数
+type DailyPlays struct {
+	Date     time.Time
+	Movies   int
+	Episodes int
+}
+
+// Source 仪表盘数据源（与命令行共用本地缓存，缓存超过 MaxAge 时重新获取）
+type Source struct {
+	LoadToken func() (*oauth2.Token, error)
+	MaxAge    time.Duration
+
+	mu sync.Mutex
+}
+
+// Load 加载仪表盘数据（refresh 为 true 时忽略缓存）
+func (s *Source) Load(refresh bool) (*Data, error) {
+	// 避免多个浏览器请求同时访问接口
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.LoadToken()
+	if err != nil {
+		return nil, fmt.Errorf("加载令牌失败：%v", err)
+	}
+	maxAge := s.MaxAge
+	if refresh {
+		maxAge = 0
+	}
+
+	data := &Data{UpdatedAt: time.Now()}
+	if data.Profile, err = utils.CachedWithin("profile", maxAge, func() (trakt.TraktUserInfo, error) {
+		var info trakt.TraktUserInfo
+		raw, err := trakt.GetUserProfile(token)
+		if err == nil {
+			err = json.Unmarshal(raw, &info)
+		}
+		return info, err
+	}); err != nil {
+		return nil, err
+	}
+	if data.Stats, err = utils.CachedWithin("stats", maxAge, func() (trakt.TraktUserStats, error) {
+		stats, err := trakt.GetUserStats(token)
+		if err != nil {
+			return trakt.TraktUserStats{}, err
+		}
+		return *stats, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -dailyDays+1)
+	since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.Local)
+	monthly, err := utils.CachedWithin("history_30d", maxAge, func() ([]trakt.TraktWatchHistoryItem, error) {
+		return trakt.GetHistoryRange(token, "", since, time.Time{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	data.Daily = dailyPlays(monthly, since)
+	if data.History, err = utils.CachedWithin("history_recent", maxAge, func() ([]trakt.TraktWatchHistoryItem, error) {
+		items, _, err := trakt.GetHistoryPage(token, 1, recentHistoryLimit)
+		return items, err
+	}); err != nil {
+		return nil, err
+	}
+
+	if data.UpNext, err = utils.CachedWithin("up_next", maxAge, func() ([]trakt.UpNextItem, error) {
+		return trakt.GetUpNext(token, trakt.UpNextOptions{SortBy: "last-watched"})
+	}); err != nil {
+		return nil, err
+	}
+	if data.Calendar, err = utils.CachedWithin("calendar", maxAge, func() ([]trakt.TraktCalendarEpisode, error) {
+		return trakt.GetMyShowsCalendar(token, time.Now(), calendarDays)
+	}); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// dailyPlays 按本地日期统计每日的电影与单集播放次数（since 为第一天零点）
+func dailyPlays(history []trakt.TraktWatchHistoryItem, since time.Time) []DailyPlays {
+	days := make([]DailyPlays, dailyDays)
+	for i := range days {
+		days[i].Date = since.AddDate(0, 0, i)
+	}
+	for _, h := range history {
+		watched := h.WatchedAt.Local()
+		i := int(time.Date(watched.Year(), watched.Month(), watched.Day(), 0, 0, 0, 0, time.Local).Sub(since).Hours()/24 + 0.5)
+		if i < 0 || i >= len(days) {
+			continue
+		}
+		if h.Type == "movie" {
+			days[i].Movies++
+		} else {
+			days[i].Episodes++
+		}
+	}
+	return days
+}
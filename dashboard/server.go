@@ -0,0 +1,154 @@
+package dashboard
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// 图表配色（电影、单集）
+var chartColors = []string{"#e8a33d", "#3d8be8"}
+
+// Handler 仪表盘页面（?refresh=1 时忽略缓存重新获取）
+type Handler struct {
+	Source *Source
+}
+
+// view 模板使用的数据
+type view struct {
+	*Data
+	DailyChart  template.HTML
+	RatingChart template.HTML
+	TotalHours  int
+}
+
+// ServeHTTP 渲染仪表盘
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	data, err := h.Source.Load(r.URL.Query().Get("refresh") == "1")
+	if err != nil {
+		log.Printf("❌ 加载仪表盘数据失败：%v", err)
+		http.Error(w, "加载数据失败："+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	v := view{Data: data, TotalHours: (data.Stats.Movies.Minutes + data.Stats.Episodes.Minutes) / 60}
+	var daily []Bar
+	for _, d := range data.Daily {
+		daily = append(daily, Bar{
+			Label:   d.Date.Format("01-02"),
+			Values:  []float64{float64(d.Movies), float64(d.Episodes)},
+			Tooltip: fmt.Sprintf("%s：电影 %d，单集 %d", d.Date.Format("2006-01-02"), d.Movies, d.Episodes),
+		})
+	}
+	v.DailyChart = BarChart(daily, chartColors, 720, 200, 5)
+
+	var ratings []Bar
+	for rating := 1; rating <= 10; rating++ {
+		n := data.Stats.Ratings.Distribution[strconv.Itoa(rating)]
+		ratings = append(ratings, Bar{Label: strconv.Itoa(rating), Values: []float64{float64(n)}, Tooltip: fmt.Sprintf("%d 分：%d 条", rating, n)})
+	}
+	v.RatingChart = BarChart(ratings, chartColors[1:], 360, 200, 1)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, v); err != nil {
+		log.Printf("❌ 渲染仪表盘失败：%v", err)
+	}
+}
+
+var pageTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"historyTitle": func(h trakt.TraktWatchHistoryItem) string {
+		return utils.FormatMediaTitle(h.Movie, h.ShowInfo(), nil, h.Episode)
+	},
+	"episodeTitle": func(show *trakt.TraktShow, episode *trakt.TraktEpisode) string {
+		return utils.FormatMediaTitle(nil, show, nil, episode)
+	},
+	"minutes": utils.FormatMinutes,
+}).Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Profile.Username}} 的观影仪表盘</title>
+<style>
+body { font-family: -apple-system, "PingFang SC", "Microsoft YaHei", sans-serif; margin: 0; background: #f4f5f7; color: #222; }
+header { background: #1d1d1f; color: #fff; padding: 16px 24px; display: flex; justify-content: space-between; align-items: baseline; }
+header a { color: #9cc4ff; }
+main { display: grid; grid-template-columns: repeat(auto-fit, minmax(360px, 1fr)); gap: 16px; padding: 16px 24px; }
+section { background: #fff; border-radius: 8px; padding: 12px 16px; box-shadow: 0 1px 2px rgba(0,0,0,.08); }
+section.wide { grid-column: 1 / -1; }
+h2 { font-size: 16px; margin: 4px 0 12px; }
+.cards { display: flex; gap: 24px; flex-wrap: wrap; }
+.card b { display: block; font-size: 24px; }
+.card span { color: #666; font-size: 13px; }
+table { width: 100%; border-collapse: collapse; font-size: 14px; }
+td { padding: 4px 0; border-bottom: 1px solid #eee; vertical-align: top; }
+td.time { color: #666; white-space: nowrap; padding-right: 12px; }
+.progress { background: #eee; border-radius: 3px; height: 6px; width: 100px; }
+.progress div { background: #3d8be8; height: 6px; border-radius: 3px; }
+.chart { width: 100%; height: auto; }
+.chart .grid { stroke: #eee; }
+.chart .axis { font-size: 10px; fill: #888; }
+.legend span { display: inline-block; width: 10px; height: 10px; margin: 0 4px 0 12px; }
+</style>
+</head>
+<body>
+<header>
+  <div><strong>{{with .Profile.Name}}{{.}}{{else}}{{.Profile.Username}}{{end}}</strong> 的观影仪表盘</div>
+  <div>更新于 {{.UpdatedAt.Format "2006-01-02 15:04"}} · <a href="/?refresh=1">刷新</a></div>
+</header>
+<main>
+<section class="wide">
+  <h2>统计</h2>
+  <div class="cards">
+    <div class="card"><b>{{.Stats.Movies.Watched}}</b><span>部电影（播放 {{.Stats.Movies.Plays}} 次）</span></div>
+    <div class="card"><b>{{.Stats.Shows.Watched}}</b><span>部剧集</span></div>
+    <div class="card"><b>{{.Stats.Episodes.Watched}}</b><span>集（播放 {{.Stats.Episodes.Plays}} 次）</span></div>
+    <div class="card"><b>{{.TotalHours}}</b><span>小时总观看时长</span></div>
+    <div class="card"><b>{{.Stats.Ratings.Total}}</b><span>条评分</span></div>
+  </div>
+</section>
+<section class="wide">
+  <h2>近30天播放 <span class="legend"><span style="background:#e8a33d"></span>电影<span style="background:#3d8be8"></span>单集</span></h2>
+  {{.DailyChart}}
+</section>
+<section>
+  <h2>评分分布</h2>
+  {{.RatingChart}}
+</section>
+<section>
+  <h2>即将播出</h2>
+  <table>
+  {{range .Calendar}}<tr><td class="time">{{.FirstAired.Local.Format "01-02 15:04"}}</td><td>{{episodeTitle .Show .Episode}}</td></tr>
+  {{else}}<tr><td>未来两周没有播出的单集</td></tr>{{end}}
+  </table>
+</section>
+<section>
+  <h2>追剧进度</h2>
+  <table>
+  {{range .UpNext}}<tr>
+    <td>{{episodeTitle .Show .Progress.NextEpisode}}<br><small>{{.Progress.Completed}}/{{.Progress.Aired}} 集{{with .RemainingMinutes}} · 剩余 {{minutes .}}{{end}}</small></td>
+    <td><div class="progress"><div style="width: {{printf "%.0f" .Percent}}%"></div></div></td>
+  </tr>
+  {{else}}<tr><td>没有未看完的剧集</td></tr>{{end}}
+  </table>
+</section>
+<section>
+  <h2>最近观看</h2>
+  <table>
+  {{range .History}}<tr><td class="time">{{.WatchedAt.Local.Format "01-02 15:04"}}</td><td>{{historyTitle .}}</td></tr>
+  {{else}}<tr><td>暂无观看记录</td></tr>{{end}}
+  </table>
+</section>
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"traktshow/backup"
+	"traktshow/utils"
+)
+
+// runDiff 比较两份备份（或本地镜像、当前账号）并报告变化
+// 参数可为备份文件路径、mirror（本地镜像）或 live（实时获取当前账号数据）
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "以JSON格式输出")
+	updateMirror := fs.Bool("update-mirror", false, "比较完成后将较新的一方保存为本地镜像（适合定时运行）")
+	failOnChange := fs.Bool("exit-code", false, "存在变化时以状态码 1 退出（便于脚本告警）")
+	fs.Parse(args)
+
+	oldRef, newRef := "mirror", "live"
+	switch fs.NArg() {
+	case 0:
+	case 2:
+		oldRef, newRef = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("用法：diff [参数] [<旧备份|mirror|live> <新备份|mirror|live>]")
+	}
+
+	oldArchive, err := loadSnapshot(oldRef)
+	if err != nil {
+		return err
+	}
+	newArchive, err := loadSnapshot(newRef)
+	if err != nil {
+		return err
+	}
+
+	diff := backup.Compare(oldArchive, newArchive)
+	if *asJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("序列化差异失败：%v", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		utils.PrintDiff(diff)
+	}
+
+	if *updateMirror {
+		if err := os.MkdirAll(utils.GetCacheDir(), 0700); err != nil {
+			return fmt.Errorf("创建缓存目录失败：%v", err)
+		}
+		if err := backup.Save(mirrorPath(), newArchive); err != nil {
+			return err
+		}
+		log.Printf("✅ 本地镜像已更新：%s", mirrorPath())
+	}
+	if *failOnChange && !diff.Empty() {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// loadSnapshot 加载快照：mirror 为本地镜像（不存在时视为空快照），live 为当前账号数据，其余按备份文件路径读取
+func loadSnapshot(ref string) (*backup.Archive, error) {
+	switch ref {
+	case "live":
+		return backup.Create(accessToken)
+	case "mirror":
+		if _, err := os.Stat(mirrorPath()); os.IsNotExist(err) {
+			log.Println("本地镜像不存在，按空快照比较")
+			return &backup.Archive{Version: backup.ArchiveVersion}, nil
+		}
+		return backup.Load(mirrorPath())
+	}
+	return backup.Load(ref)
+}
+
+// mirrorPath 本地镜像文件路径
+func mirrorPath() string {
+	return filepath.Join(utils.GetCacheDir(), "mirror.json.gz")
+}
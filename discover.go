@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runDiscover 浏览电影/剧集发现榜单（并标记已看过的条目）
+func runDiscover(args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ExitOnError)
+	mediaType := fs.String("type", "movies", "榜单类型：movies 或 shows")
+	var opts trakt.DiscoverOptions
+	fs.StringVar(&opts.Period, "period", "weekly", "统计周期（watched/played/collected）：daily、weekly、monthly、yearly、all")
+	fs.StringVar(&opts.Genres, "genre", "", "类型（逗号分隔），如 action,drama")
+	fs.StringVar(&opts.Years, "year", "", "年份或范围，如 2020 或 2010-2019")
+	fs.StringVar(&opts.Countries, "country", "", "国家代码（逗号分隔），如 us,cn")
+	fs.StringVar(&opts.Languages, "language", "", "语言代码（逗号分隔），如 en,zh")
+	fs.StringVar(&opts.Runtimes, "runtime", "", "时长范围（分钟），如 30-90")
+	fs.StringVar(&opts.Certifications, "certification", "", "分级（逗号分隔），如 pg-13,tv-ma")
+	fs.IntVar(&opts.Limit, "limit", 20, "返回条目数")
+	hideWatched := fs.Bool("unwatched", false, "只显示未看过的条目")
+	fs.Parse(args)
+
+	feed := "trending"
+	if fs.NArg() > 0 {
+		feed = fs.Arg(0)
+	}
+	if !slices.Contains(trakt.DiscoverFeeds, feed) {
+		return fmt.Errorf("不支持的榜单：%s（可用：%s）", feed, strings.Join(trakt.DiscoverFeeds, "、"))
+	}
+	if *mediaType != "movies" && *mediaType != "shows" {
+		return fmt.Errorf("不支持的榜单类型：%s", *mediaType)
+	}
+
+	items, err := trakt.Discover(accessToken, *mediaType, feed, opts)
+	if err != nil {
+		return err
+	}
+	watched, err := watchedIDs(*mediaType)
+	if err != nil {
+		return err
+	}
+	if *hideWatched {
+		items = slices.DeleteFunc(items, func(item trakt.DiscoverItem) bool {
+			return watched[item.TraktID()]
+		})
+	}
+	utils.PrintDiscover(feed, items, watched)
+	return nil
+}
+
+// watchedIDs 获取用户已观看的电影或剧集的 Trakt ID 集合
+func watchedIDs(mediaType string) (map[int]bool, error) {
+	ids := make(map[int]bool)
+	if mediaType == "movies" {
+		movies, err := trakt.GetWatchedMovies(accessToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range movies {
+			ids[m.Movie.IDs.Trakt] = true
+		}
+		return ids, nil
+	}
+
+	shows, err := trakt.GetWatchedShows(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range shows {
+		ids[s.Show.IDs.Trakt] = true
+	}
+	return ids, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"traktshow/exporter"
+	"traktshow/trakt"
+)
+
+// runExport 导出电影观看记录与评分（目前支持 Letterboxd 导入格式）
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "letterboxd", "导出格式：letterboxd")
+	output := fs.String("o", "", "输出文件路径（默认输出到标准输出）")
+	fs.Parse(args)
+
+	if *format != "letterboxd" {
+		return fmt.Errorf("不支持的导出格式：%s", *format)
+	}
+
+	history, err := trakt.GetHistoryRange(accessToken, "movies", time.Time{}, time.Time{})
+	if err != nil {
+		return err
+	}
+	ratings, err := trakt.GetRatings(accessToken, "movies")
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("创建输出文件失败：%v", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	rows, err := exporter.WriteLetterboxdCSV(w, history, ratings)
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		log.Printf("✅ 已导出 %d 条记录到 %s", rows, *output)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"traktshow/trakt"
+)
+
+// letterboxdHeader Letterboxd 导入CSV的列
+var letterboxdHeader = []string{"Title", "Year", "imdbID", "WatchedDate", "Rating10", "Rewatch"}
+
+// WriteLetterboxdCSV 将电影观看记录与评分导出为 Letterboxd 导入格式的CSV
+// 每次观看输出一行（同一电影的第二次及以后的观看标记为 Rewatch）；只有评分而无观看记录的电影输出一行不带观看日期的记录
+func WriteLetterboxdCSV(w io.Writer, history []trakt.TraktWatchHistoryItem, ratings []trakt.TraktRating) (int, error) {
+	index := trakt.NewRatingIndex(ratings)
+
+	// 按观看时间从早到晚排列，用于判断重看
+	var plays []trakt.TraktWatchHistoryItem
+	for _, item := range history {
+		if item.Type == "movie" && item.Movie != nil {
+			plays = append(plays, item)
+		}
+	}
+	sort.SliceStable(plays, func(i, j int) bool {
+		return plays[i].WatchedAt.Before(plays[j].WatchedAt)
+	})
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(letterboxdHeader); err != nil {
+		return 0, fmt.Errorf("写入CSV失败：%v", err)
+	}
+
+	rows := 0
+	watched := make(map[int]bool)
+	for _, item := range plays {
+		movie := item.Movie
+		rating := ""
+		if r := index.Get("movie", movie.IDs.Trakt); r > 0 {
+			rating = strconv.Itoa(r)
+		}
+		record := []string{
+			movie.Title,
+			yearString(movie.Year),
+			movie.IDs.IMDB,
+			item.WatchedAt.Local().Format("2006-01-02"),
+			rating,
+			strconv.FormatBool(watched[movie.IDs.Trakt]),
+		}
+		if err := writer.Write(record); err != nil {
+			return rows, fmt.Errorf("写入CSV失败：%v", err)
+		}
+		watched[movie.IDs.Trakt] = true
+		rows++
+	}
+
+	// 只有评分、没有观看记录的电影
+	for _, r := range ratings {
+		if r.Type != "movie" || r.Movie == nil || watched[r.Movie.IDs.Trakt] {
+			continue
+		}
+		record := []string{r.Movie.Title, yearString(r.Movie.Year), r.Movie.IDs.IMDB, "", strconv.Itoa(r.Rating), "false"}
+		if err := writer.Write(record); err != nil {
+			return rows, fmt.Errorf("写入CSV失败：%v", err)
+		}
+		rows++
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return rows, fmt.Errorf("写入CSV失败：%v", err)
+	}
+	return rows, nil
+}
+
+// yearString 年份转字符串（未知年份为空）
+func yearString(year int) string {
+	if year == 0 {
+		return ""
+	}
+	return strconv.Itoa(year)
+}
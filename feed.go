@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"traktshow/feed"
+	"traktshow/utils"
+)
+
+// runFeed 将最近的观看记录生成为 RSS 2.0 / Atom 静态文件（均未指定时以 RSS 输出到标准输出）
+func runFeed(args []string) error {
+	fs := flag.NewFlagSet("feed", flag.ExitOnError)
+	rssPath := fs.String("rss", "", "RSS 2.0 输出文件")
+	atomPath := fs.String("atom", "", "Atom 输出文件")
+	limit := fs.Int("limit", 50, "条目数")
+	fs.Parse(args)
+
+	if *limit <= 0 {
+		return fmt.Errorf("条目数必须大于0")
+	}
+	source := &feed.Source{LoadToken: utils.LoadToken, Limit: *limit}
+	meta, entries, err := source.Load()
+	if err != nil {
+		return err
+	}
+
+	if *rssPath == "" && *atomPath == "" {
+		return feed.WriteRSS(os.Stdout, meta, entries)
+	}
+	write := func(path string, writeFeed func(*os.File) error) error {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("创建输出文件失败：%v", err)
+		}
+		if err := writeFeed(file); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		log.Printf("✅ 已生成 %s（%d 条）", path, len(entries))
+		return nil
+	}
+	if *rssPath != "" {
+		if err := write(*rssPath, func(f *os.File) error { return feed.WriteRSS(f, meta, entries) }); err != nil {
+			return err
+		}
+	}
+	if *atomPath != "" {
+		if err := write(*atomPath, func(f *os.File) error { return feed.WriteAtom(f, meta, entries) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
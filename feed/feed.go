@@ -0,0 +1,218 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// Meta 订阅源的基本信息
+type Meta struct {
+	Title   string
+	Author  string // 用户名
+	Link    string // 用户主页（如 https://trakt.tv/users/name）
+	SelfURL string // 订阅源自身的地址（Atom 需要，静态文件时可为空）
+}
+
+// Entry 订阅源中的一条观看记录
+type Entry struct {
+	ID        string // 全局唯一标识（基于观看记录ID）
+	Title     string
+	Link      string // Trakt 页面
+	IMDbLink  string
+	WatchedAt time.Time
+	Overview  string
+}
+
+// Entries 将观看记录转换为订阅条目
+func Entries(history []trakt.TraktWatchHistoryItem) []Entry {
+	entries := make([]Entry, 0, len(history))
+	for i := range history {
+		h := &history[i]
+		show := h.ShowInfo()
+		e := Entry{
+			ID:        fmt.Sprintf("tag:trakt.tv,2010:history/%d", h.ID),
+			Title:     utils.FormatMediaTitle(h.Movie, show, nil, h.Episode),
+			WatchedAt: h.WatchedAt,
+		}
+		switch {
+		case h.Movie != nil:
+			e.Title = "电影：" + e.Title
+			e.Link = "https://trakt.tv/movies/" + h.Movie.IDs.Slug
+			e.IMDbLink = imdbLink(h.Movie.IDs.IMDB)
+			e.Overview = h.Movie.Overview
+		case show != nil && h.Episode != nil:
+			e.Link = fmt.Sprintf("https://trakt.tv/shows/%s/seasons/%d/episodes/%d", show.IDs.Slug, h.Episode.Season, h.Episode.Number)
+			e.IMDbLink = imdbLink(h.Episode.IDs.IMDB)
+			if e.IMDbLink == "" {
+				e.IMDbLink = imdbLink(show.IDs.IMDB)
+			}
+			e.Overview = h.Episode.Overview
+			if e.Overview == "" {
+				e.Overview = show.Overview
+			}
+		default:
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// imdbLink IMDb 页面地址（没有 IMDb ID 时为空）
+func imdbLink(id string) string {
+	if id == "" {
+		return ""
+	}
+	return "https://www.imdb.com/title/" + id + "/"
+}
+
+// content 条目正文（HTML：简介与链接）
+func (e *Entry) content() string {
+	var b strings.Builder
+	if e.Overview != "" {
+		fmt.Fprintf(&b, "<p>%s</p>", html.EscapeString(e.Overview))
+	}
+	fmt.Fprintf(&b, "<p>观看时间：%s</p><p>", e.WatchedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, `<a href="%s">Trakt</a>`, html.EscapeString(e.Link))
+	if e.IMDbLink != "" {
+		fmt.Fprintf(&b, ` · <a href="%s">IMDb</a>`, html.EscapeString(e.IMDbLink))
+	}
+	b.WriteString("</p>")
+	return b.String()
+}
+
+// RSS 2.0 文档结构
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// WriteRSS 输出 RSS 2.0 订阅源
+func WriteRSS(w io.Writer, meta Meta, entries []Entry) error {
+	doc := rssDocument{Version: "2.0", Channel: rssChannel{
+		Title:         meta.Title,
+		Link:          meta.Link,
+		Description:   meta.Title,
+		LastBuildDate: time.Now().Format(time.RFC1123Z),
+	}}
+	for i := range entries {
+		e := &entries[i]
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			GUID:        rssGUID{Value: e.ID},
+			PubDate:     e.WatchedAt.Format(time.RFC1123Z),
+			Description: e.content(),
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// Atom 文档结构
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteAtom 输出 Atom 订阅源（feed 的更新时间取最近一条观看记录）
+func WriteAtom(w io.Writer, meta Meta, entries []Entry) error {
+	updated := time.Now()
+	if len(entries) > 0 {
+		updated = entries[0].WatchedAt
+	}
+	feed := atomFeed{
+		Title:   meta.Title,
+		ID:      meta.Link + "/history",
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links:   []atomLink{{Href: meta.Link + "/history", Rel: "alternate"}},
+		Author:  atomAuthor{Name: meta.Author},
+	}
+	if meta.SelfURL != "" {
+		feed.Links = append(feed.Links, atomLink{Href: meta.SelfURL, Rel: "self"})
+	}
+	for i := range entries {
+		e := &entries[i]
+		entry := atomEntry{
+			Title:   e.Title,
+			ID:      e.ID,
+			Updated: e.WatchedAt.UTC().Format(time.RFC3339),
+			Links:   []atomLink{{Href: e.Link, Rel: "alternate"}},
+			Content: atomContent{Type: "html", Value: e.content()},
+		}
+		if e.IMDbLink != "" {
+			entry.Links = append(entry.Links, atomLink{Href: e.IMDbLink, Rel: "related"})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return writeXML(w, feed)
+}
+
+// writeXML 输出带声明的缩进 XML
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("生成订阅源失败：%v", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,96 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// Source 订阅源数据（与命令行共用本地缓存，缓存超过 MaxAge 时重新获取）
+type Source struct {
+	LoadToken func() (*oauth2.Token, error)
+	MaxAge    time.Duration
+	Limit     int // 条目数
+
+	mu sync.Mutex
+}
+
+// Load 获取订阅源信息与最近的观看记录
+func (s *Source) Load() (Meta, []Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.LoadToken()
+	if err != nil {
+		return Meta{}, nil, fmt.Errorf("加载令牌失败：%v", err)
+	}
+	profile, err := utils.CachedWithin("profile", s.MaxAge, func() (trakt.TraktUserInfo, error) {
+		var info trakt.TraktUserInfo
+		raw, err := trakt.GetUserProfile(token)
+		if err == nil {
+			err = json.Unmarshal(raw, &info)
+		}
+		return info, err
+	})
+	if err != nil {
+		return Meta{}, nil, err
+	}
+	history, err := utils.CachedWithin(fmt.Sprintf("history_feed_%d", s.Limit), s.MaxAge, func() ([]trakt.TraktWatchHistoryItem, error) {
+		items, _, err := trakt.GetHistoryPage(token, 1, s.Limit)
+		return items, err
+	})
+	if err != nil {
+		return Meta{}, nil, err
+	}
+
+	// 个人主页地址使用 slug（旧缓存中没有 slug 时退回到小写的用户名）
+	slug := profile.IDs.Slug
+	if slug == "" {
+		slug = strings.ToLower(profile.Username)
+	}
+	meta := Meta{
+		Title:  profile.Username + " 的观看记录",
+		Author: profile.Username,
+		Link:   "https://trakt.tv/users/" + slug,
+	}
+	return meta, Entries(history), nil
+}
+
+// Handler 通过 HTTP 提供订阅源（路径以 .atom 结尾时输出 Atom，否则输出 RSS）
+type Handler struct {
+	Source *Source
+}
+
+// ServeHTTP 输出订阅源
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	meta, entries, err := h.Source.Load()
+	if err != nil {
+		log.Printf("❌ 生成订阅源失败：%v", err)
+		http.Error(w, "生成订阅源失败："+err.Error(), http.StatusBadGateway)
+		return
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	meta.SelfURL = scheme + "://" + r.Host + r.URL.Path
+
+	if strings.HasSuffix(r.URL.Path, ".atom") {
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		err = WriteAtom(w, meta, entries)
+	} else {
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		err = WriteRSS(w, meta, entries)
+	}
+	if err != nil {
+		log.Printf("❌ 输出订阅源失败：%v", err)
+	}
+}
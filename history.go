@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runHistory 观看记录管理（add/remove）
+func runHistory(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法：history add|remove [参数] [条目...]")
+	}
+
+	switch args[0] {
+	case "add":
+		return addHistory(args[1:])
+	case "remove":
+		return removeHistory(args[1:])
+	}
+	return fmt.Errorf("未知的子命令：%s（可用：add、remove）", args[0])
+}
+
+// addHistory 将电影、单集、整季或整部剧标记为已观看
+func addHistory(args []string) error {
+	fs := flag.NewFlagSet("history add", flag.ExitOnError)
+	target := addItemFlags(fs)
+	at := fs.String("at", "now", "观看时间：now、released（首播/上映时间）或具体时间（如 2024-01-02 20:30）")
+	dryRun := fs.Bool("dry-run", false, "仅预览，不实际提交")
+	fs.Parse(args)
+
+	watchedAt, err := parseWatchedAt(*at)
+	if err != nil {
+		return err
+	}
+	items, err := target.resolve(fs.Args())
+	if err != nil {
+		return err
+	}
+	items.SetWatchedAt(watchedAt)
+
+	if *dryRun {
+		return printDryRun("将添加以下观看记录", items)
+	}
+	result, err := trakt.AddToHistory(accessToken, items)
+	if err != nil {
+		return err
+	}
+	utils.PrintSyncResult(result)
+	return nil
+}
+
+// removeHistory 按记录ID、时间范围或条目删除观看记录
+func removeHistory(args []string) error {
+	fs := flag.NewFlagSet("history remove", flag.ExitOnError)
+	target := addItemFlags(fs)
+	idList := fs.String("ids", "", "要删除的观看记录ID（逗号分隔）")
+	from := fs.String("from", "", "删除该时间之后的记录（如 2024-01-02 或 2024-01-02 20:30）")
+	to := fs.String("to", "", "删除该时间之前的记录")
+	only := fs.String("only", "", "按时间范围删除时只删除指定类型：movies 或 episodes")
+	dryRun := fs.Bool("dry-run", false, "仅预览，不实际删除")
+	fs.Parse(args)
+
+	var items trakt.SyncItems
+	switch {
+	case *idList != "":
+		for _, s := range strings.Split(*idList, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return fmt.Errorf("无效的观看记录ID：%s", s)
+			}
+			items.IDs = append(items.IDs, id)
+		}
+
+	case *from != "" || *to != "":
+		start, end, err := parseTimeRange(*from, *to)
+		if err != nil {
+			return err
+		}
+		history, err := trakt.GetHistoryRange(accessToken, *only, start, end)
+		if err != nil {
+			return err
+		}
+		if len(history) == 0 {
+			fmt.Println("指定时间范围内没有观看记录")
+			return nil
+		}
+		fmt.Printf("时间范围内共 %d 条观看记录：\n", len(history))
+		utils.PrintHistoryBrief(history)
+		for _, item := range history {
+			items.IDs = append(items.IDs, item.ID)
+		}
+
+	default:
+		resolved, err := target.resolve(fs.Args())
+		if err != nil {
+			return err
+		}
+		items = resolved
+	}
+
+	if *dryRun {
+		return printDryRun("将删除以下观看记录", items)
+	}
+	result, err := trakt.RemoveFromHistory(accessToken, items)
+	if err != nil {
+		return err
+	}
+	utils.PrintSyncResult(result)
+	return nil
+}
+
+// printDryRun 打印预览模式下将要提交的请求内容
+func printDryRun(title string, items trakt.SyncItems) error {
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化请求失败：%v", err)
+	}
+	fmt.Printf("[预览] %s（共 %d 项，未实际提交）：\n%s\n", title, items.Len(), data)
+	return nil
+}
+
+// parseWatchedAt 解析观看时间参数（now、released 或具体时间）
+func parseWatchedAt(value string) (string, error) {
+	switch value {
+	case "now":
+		return time.Now().UTC().Format(time.RFC3339), nil
+	case "released":
+		return "released", nil
+	}
+	t, err := parseTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// parseTimeRange 解析时间范围（仅指定日期时，结束时间包含当天）
+func parseTimeRange(from, to string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = parseTime(from); err != nil {
+			return start, end, err
+		}
+	}
+	if to != "" {
+		if end, err = parseTime(to); err != nil {
+			return start, end, err
+		}
+		if len(to) == len("2006-01-02") {
+			end = end.AddDate(0, 0, 1).Add(-time.Second)
+		}
+	}
+	return start, end, nil
+}
+
+// parseTime 解析本地时间（支持 RFC3339、2006-01-02 15:04 和 2006-01-02）
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法识别的时间格式：%s", value)
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"traktshow/importer"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// importState 导入进度（保存在本地缓存中，用于断点续传）
+type importState struct {
+	Resolved map[string]importMatch `json:"resolved"` // 记录键 → 匹配结果
+	Done     map[string]bool        `json:"done"`     // 已提交的记录键
+}
+
+// importMatch 记录与 Trakt 条目的匹配结果
+type importMatch struct {
+	Kind string         `json:"kind"` // movie、show、episode（Skip 为 true 时为空）
+	IDs  trakt.TraktIDs `json:"ids"`
+	Skip bool           `json:"skip"` // 未找到或用户选择跳过
+}
+
+// runImport 从 IMDb、Letterboxd、TV Time 导出文件导入观看记录、评分与观看清单
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	source := fs.String("source", "", "导入来源：imdb-ratings、imdb-watchlist、letterboxd、tvtime")
+	watched := fs.Bool("watched", false, "IMDb 评分导入时同时将电影/单集标记为已观看（观看时间取评分时间）")
+	batchSize := fs.Int("batch", 100, "每批提交的记录数")
+	yes := fs.Bool("yes", false, "不逐条确认，存在歧义时自动采用最佳匹配")
+	dryRun := fs.Bool("dry-run", false, "仅匹配与预览，不实际提交")
+	restart := fs.Bool("restart", false, "忽略上次的导入进度，从头开始")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || *source == "" {
+		return fmt.Errorf("用法：import -source <来源> [参数] <导出文件>")
+	}
+	if *batchSize <= 0 {
+		return fmt.Errorf("每批记录数必须大于0")
+	}
+	path, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取导出文件失败：%v", err)
+	}
+	entries, err := importer.Parse(*source, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	if *watched {
+		for i := range entries {
+			if entries[i].Kind != "show" && entries[i].WatchedAt.IsZero() {
+				entries[i].WatchedAt = entries[i].RatedAt
+			}
+		}
+	}
+	log.Printf("共解析 %d 条记录", len(entries))
+
+	// 加载上次的导入进度
+	stateName := importStateName(*source, path, content)
+	state := importState{Resolved: make(map[string]importMatch), Done: make(map[string]bool)}
+	if !*restart {
+		if _, err := utils.LoadCache(stateName, &state); err == nil {
+			log.Printf("继续上次的导入：已匹配 %d 条，已提交 %d 条", len(state.Resolved), len(state.Done))
+		}
+	}
+
+	// 1. 匹配 Trakt 条目
+	reader := bufio.NewReader(os.Stdin)
+	var pending []importer.Entry
+	for i := range entries {
+		entry := &entries[i]
+		if state.Done[entry.Key()] {
+			continue
+		}
+		if _, ok := state.Resolved[entry.Key()]; !ok {
+			match, err := matchImportEntry(entry, *yes, reader)
+			if err != nil {
+				saveImportState(stateName, &state)
+				return err
+			}
+			state.Resolved[entry.Key()] = match
+			if len(state.Resolved)%20 == 0 {
+				saveImportState(stateName, &state)
+			}
+		}
+		if !state.Resolved[entry.Key()].Skip {
+			pending = append(pending, *entry)
+		}
+	}
+	saveImportState(stateName, &state)
+
+	skipped := 0
+	for _, match := range state.Resolved {
+		if match.Skip {
+			skipped++
+		}
+	}
+	fmt.Printf("待提交 %d 条，跳过（未找到或已忽略）%d 条\n", len(pending), skipped)
+
+	// 2. 分批提交
+	for start := 0; start < len(pending); start += *batchSize {
+		batch := pending[start:min(start+*batchSize, len(pending))]
+		var history, ratings, watchlist trakt.SyncItems
+		for i := range batch {
+			addImportEntry(&batch[i], state.Resolved[batch[i].Key()], &history, &ratings, &watchlist)
+		}
+
+		if *dryRun {
+			fmt.Printf("[预览] 第 %d-%d 条：观看记录 %d，评分 %d，观看清单 %d（未实际提交）\n",
+				start+1, start+len(batch), history.Len(), ratings.Len(), watchlist.Len())
+			continue
+		}
+		if err := submitImportBatch(history, ratings, watchlist); err != nil {
+			saveImportState(stateName, &state)
+			return fmt.Errorf("第 %d-%d 条提交失败（可重新运行以继续）：%v", start+1, start+len(batch), err)
+		}
+		for i := range batch {
+			state.Done[batch[i].Key()] = true
+		}
+		saveImportState(stateName, &state)
+		log.Printf("✅ 已提交第 %d-%d 条", start+1, start+len(batch))
+	}
+
+	// 全部提交完成后不再需要断点续传
+	if !*dryRun {
+		if err := utils.DeleteCache(stateName); err != nil {
+			log.Printf("⚠️  清除导入进度失败：%v", err)
+		}
+	}
+	return nil
+}
+
+// matchImportEntry 将导出记录匹配为 Trakt 条目（有外部ID时按ID查找，否则按标题与年份搜索）
+func matchImportEntry(entry *importer.Entry, yes bool, reader *bufio.Reader) (importMatch, error) {
+	// TV Time 记录带有单集的 TVDB ID，可直接提交
+	if entry.Kind == "episode" && entry.TVDB > 0 {
+		return importMatch{Kind: "episode", IDs: trakt.TraktIDs{TVDB: entry.TVDB}}, nil
+	}
+
+	if entry.IMDB != "" {
+		results, err := trakt.LookupID(accessToken, "imdb", entry.IMDB, entry.Kind)
+		if err != nil {
+			return importMatch{}, err
+		}
+		if len(results) == 0 {
+			log.Printf("⚠️  未找到：%s（%s）", entry.Describe(), entry.IMDB)
+			return importMatch{Skip: true}, nil
+		}
+		return importMatch{Kind: results[0].Type, IDs: results[0].IDs()}, nil
+	}
+
+	// 带有剧集 TVDB ID 的单集按剧集 + 季号/集号提交
+	if entry.ShowTVDB > 0 {
+		return importMatch{Kind: "show", IDs: trakt.TraktIDs{TVDB: entry.ShowTVDB}}, nil
+	}
+
+	// 按标题搜索（单集先匹配所属剧集）
+	searchType := entry.Kind
+	if searchType == "episode" {
+		searchType = "show"
+	}
+	opts := trakt.SearchOptions{Fields: []string{"title", "original_title", "aliases"}, Limit: 10}
+	if entry.Year > 0 && searchType == "movie" {
+		opts.Years = strconv.Itoa(entry.Year)
+	}
+	results, err := trakt.Search(accessToken, []string{searchType}, entry.Title, opts)
+	if err != nil {
+		return importMatch{}, err
+	}
+
+	best, ambiguous := importer.PickMatch(entry, results)
+	if best == nil {
+		log.Printf("⚠️  未找到：%s", entry.Describe())
+		return importMatch{Skip: true}, nil
+	}
+	if ambiguous && !yes {
+		best = reviewImportMatch(entry, results, reader)
+		if best == nil {
+			return importMatch{Skip: true}, nil
+		}
+	}
+	return importMatch{Kind: searchType, IDs: best.IDs()}, nil
+}
+
+// reviewImportMatch 存在歧义时让用户选择匹配条目（返回 nil 表示跳过）
+func reviewImportMatch(entry *importer.Entry, results []trakt.TraktSearchResult, reader *bufio.Reader) *trakt.TraktSearchResult {
+	if len(results) > 5 {
+		results = results[:5]
+	}
+	fmt.Printf("\n「%s」（第 %d 行）匹配到多个条目：\n", entry.Describe(), entry.Line)
+	for i := range results {
+		fmt.Printf("  %d. %s (%d)  trakt:%d\n", i+1, results[i].Title(), results[i].Year(), results[i].IDs().Trakt)
+	}
+	for {
+		fmt.Print("请输入序号选择，s 跳过（直接回车选择第1个）：")
+		line, _ := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		switch line {
+		case "":
+			return &results[0]
+		case "s":
+			return nil
+		}
+		if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(results) {
+			return &results[n-1]
+		}
+	}
+}
+
+// addImportEntry 将记录按需加入观看记录、评分与观看清单的提交内容
+func addImportEntry(entry *importer.Entry, match importMatch, history, ratings, watchlist *trakt.SyncItems) {
+	watchedAt, ratedAt := "", ""
+	if !entry.WatchedAt.IsZero() {
+		watchedAt = entry.WatchedAt.UTC().Format(time.RFC3339)
+	}
+	if !entry.RatedAt.IsZero() {
+		ratedAt = entry.RatedAt.UTC().Format(time.RFC3339)
+	}
+
+	// 按剧集 + 季号/集号定位的单集
+	if entry.Kind == "episode" && match.Kind != "episode" {
+		season := func(episode trakt.SyncEpisode) []trakt.SyncSeason {
+			return []trakt.SyncSeason{{Number: entry.Season, Episodes: []trakt.SyncEpisode{episode}}}
+		}
+		if watchedAt != "" {
+			history.Shows = append(history.Shows, trakt.SyncItem{IDs: match.IDs, Seasons: season(trakt.SyncEpisode{Number: entry.Episode, WatchedAt: watchedAt})})
+		}
+		if entry.Rating > 0 {
+			ratings.Shows = append(ratings.Shows, trakt.SyncItem{IDs: match.IDs, Seasons: season(trakt.SyncEpisode{Number: entry.Episode, Rating: entry.Rating})})
+		}
+		if entry.Watchlist {
+			watchlist.Shows = append(watchlist.Shows, trakt.SyncItem{IDs: match.IDs, Seasons: season(trakt.SyncEpisode{Number: entry.Episode})})
+		}
+		return
+	}
+
+	var list func(items *trakt.SyncItems) *[]trakt.SyncItem
+	switch match.Kind {
+	case "movie":
+		list = func(items *trakt.SyncItems) *[]trakt.SyncItem { return &items.Movies }
+	case "show":
+		list = func(items *trakt.SyncItems) *[]trakt.SyncItem { return &items.Shows }
+	case "episode":
+		list = func(items *trakt.SyncItems) *[]trakt.SyncItem { return &items.Episodes }
+	default:
+		return
+	}
+
+	// 整部剧集不写入观看记录（会将所有单集标记为已看）
+	if watchedAt != "" && match.Kind != "show" {
+		*list(history) = append(*list(history), trakt.SyncItem{IDs: match.IDs, WatchedAt: watchedAt})
+	}
+	if entry.Rating > 0 {
+		*list(ratings) = append(*list(ratings), trakt.SyncItem{IDs: match.IDs, Rating: entry.Rating, RatedAt: ratedAt})
+	}
+	if entry.Watchlist {
+		*list(watchlist) = append(*list(watchlist), trakt.SyncItem{IDs: match.IDs})
+	}
+}
+
+// submitImportBatch 提交一批观看记录、评分与观看清单
+func submitImportBatch(history, ratings, watchlist trakt.SyncItems) error {
+	if history.Len() > 0 {
+		result, err := trakt.AddToHistory(accessToken, history)
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+	}
+	if ratings.Len() > 0 {
+		result, err := trakt.AddRatings(accessToken, ratings)
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+	}
+	if watchlist.Len() > 0 {
+		result, err := trakt.AddToWatchlist(accessToken, watchlist)
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+	}
+	return nil
+}
+
+// importStateName 导入进度的缓存名称（按来源、文件路径与文件内容区分，同一路径下的新导出文件从头开始）
+func importStateName(source, path string, content []byte) string {
+	h := sha1.New()
+	h.Write([]byte(source + "|" + path + "|"))
+	h.Write(content)
+	return "import_" + hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// saveImportState 保存导入进度（失败时仅记录日志）
+func saveImportState(name string, state *importState) {
+	if err := utils.SaveCache(name, state); err != nil {
+		log.Printf("⚠️  保存导入进度失败：%v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+)
+
+// ParseIMDbRatings 解析 IMDb 评分导出（ratings.csv：Const、Your Rating、Date Rated、Title、Title Type、Year 等列）
+func ParseIMDbRatings(r io.Reader) ([]Entry, error) {
+	table, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if !table.has("Const") || !table.has("Your Rating") {
+		return nil, fmt.Errorf("不是有效的 IMDb 评分导出文件（缺少 Const 或 Your Rating 列）")
+	}
+
+	var entries []Entry
+	for i, row := range table.rows {
+		kind := imdbKind(table.get(row, "Title Type"))
+		if kind == "" {
+			continue
+		}
+		entries = append(entries, Entry{
+			Line:    i + 2,
+			Kind:    kind,
+			Title:   table.get(row, "Title"),
+			Year:    table.getInt(row, "Year"),
+			IMDB:    table.get(row, "Const"),
+			Rating:  table.getInt(row, "Your Rating"),
+			RatedAt: parseDate(table.get(row, "Date Rated")),
+		})
+	}
+	return entries, nil
+}
+
+// ParseIMDbWatchlist 解析 IMDb 观看清单导出（watchlist.csv：Const、Created、Title、Title Type、Year 等列）
+func ParseIMDbWatchlist(r io.Reader) ([]Entry, error) {
+	table, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if !table.has("Const") {
+		return nil, fmt.Errorf("不是有效的 IMDb 观看清单导出文件（缺少 Const 列）")
+	}
+
+	var entries []Entry
+	for i, row := range table.rows {
+		kind := imdbKind(table.get(row, "Title Type"))
+		if kind == "" {
+			continue
+		}
+		entries = append(entries, Entry{
+			Line:      i + 2,
+			Kind:      kind,
+			Title:     table.get(row, "Title"),
+			Year:      table.getInt(row, "Year"),
+			IMDB:      table.get(row, "Const"),
+			Watchlist: true,
+		})
+	}
+	return entries, nil
+}
+
+// imdbKind 将 IMDb 的 Title Type 映射为条目类型（不支持的类型返回空字符串）
+func imdbKind(titleType string) string {
+	switch titleType {
+	case "movie", "Movie", "tvMovie", "TV Movie", "video", "Video", "short", "Short":
+		return "movie"
+	case "tvSeries", "TV Series", "tvMiniSeries", "TV Mini Series":
+		return "show"
+	case "tvEpisode", "TV Episode":
+		return "episode"
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"traktshow/trakt"
+)
+
+// Entry 从导出文件中解析出的单条记录
+type Entry struct {
+	Line      int       // 在源文件中的行号（用于断点续传）
+	Kind      string    // movie、show、episode
+	Title     string    // 电影/剧集标题
+	Year      int       // 年份（未知时为0）
+	IMDB      string    // IMDb ID（电影、剧集或单集）
+	TVDB      int       // TVDB ID（单集记录时为单集的 TVDB ID）
+	ShowTVDB  int       // 所属剧集的 TVDB ID（仅单集）
+	Season    int       // 季号（仅单集）
+	Episode   int       // 集号（仅单集）
+	WatchedAt time.Time // 观看时间（零值表示不写入观看记录）
+	Rating    int       // 评分 1-10（0 表示不评分）
+	RatedAt   time.Time // 评分时间
+	Watchlist bool      // 是否加入观看清单
+}
+
+// Key 记录的唯一键（用于断点续传时跳过已提交的记录）
+// 按记录内容生成而不使用行号，导出文件增删行后已匹配与已提交的记录仍能对应
+func (e *Entry) Key() string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s|%d|%d|%d|%s|%d|%t",
+		e.Kind, e.IMDB, e.TVDB, e.ShowTVDB, strings.ToLower(e.Title), e.Year, e.Season, e.Episode,
+		e.WatchedAt.UTC().Format(time.RFC3339), e.Rating, e.Watchlist)
+}
+
+// Describe 记录的可读描述
+func (e *Entry) Describe() string {
+	if e.Kind == "episode" {
+		return fmt.Sprintf("%s S%02dE%02d", e.Title, e.Season, e.Episode)
+	}
+	if e.Year > 0 {
+		return fmt.Sprintf("%s (%d)", e.Title, e.Year)
+	}
+	return e.Title
+}
+
+// Parse 按来源解析导出文件（source：imdb-ratings、imdb-watchlist、letterboxd、tvtime）
+func Parse(source string, r io.Reader) ([]Entry, error) {
+	switch source {
+	case "imdb-ratings":
+		return ParseIMDbRatings(r)
+	case "imdb-watchlist":
+		return ParseIMDbWatchlist(r)
+	case "letterboxd":
+		return ParseLetterboxdDiary(r)
+	case "tvtime":
+		return ParseTVTime(r)
+	}
+	return nil, fmt.Errorf("不支持的导入来源：%s（可用：imdb-ratings、imdb-watchlist、letterboxd、tvtime）", source)
+}
+
+// PickMatch 从搜索结果中选出与记录匹配的条目（标题与年份唯一匹配时 ambiguous 为 false）
+func PickMatch(e *Entry, results []trakt.TraktSearchResult) (best *trakt.TraktSearchResult, ambiguous bool) {
+	if len(results) == 0 {
+		return nil, false
+	}
+	if len(results) == 1 {
+		return &results[0], false
+	}
+
+	var exact []int
+	for i := range results {
+		if strings.EqualFold(results[i].Title(), e.Title) && (e.Year == 0 || results[i].Year() == e.Year) {
+			exact = append(exact, i)
+		}
+	}
+	if len(exact) == 1 {
+		return &results[exact[0]], false
+	}
+	// 无唯一匹配时，年份一致的结果优先
+	for i := range results {
+		if e.Year > 0 && results[i].Year() == e.Year {
+			return &results[i], true
+		}
+	}
+	return &results[0], true
+}
+
+// csvTable 按表头名称读取CSV的辅助结构
+type csvTable struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+// readCSV 读取带表头的CSV（表头名称不区分大小写）
+func readCSV(r io.Reader) (*csvTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("读取CSV失败：%v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV文件为空")
+	}
+
+	table := &csvTable{columns: make(map[string]int), rows: records[1:]}
+	for i, name := range records[0] {
+		// 去掉 UTF-8 BOM
+		name = strings.TrimPrefix(name, "\ufeff")
+		table.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	return table, nil
+}
+
+// has 表头中是否包含指定列
+func (t *csvTable) has(name string) bool {
+	_, ok := t.columns[strings.ToLower(name)]
+	return ok
+}
+
+// get 读取指定行的列值（依次尝试多个列名，均不存在时返回空字符串）
+func (t *csvTable) get(row []string, names ...string) string {
+	for _, name := range names {
+		if i, ok := t.columns[strings.ToLower(name)]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+	}
+	return ""
+}
+
+// getInt 读取整数列（无法解析时返回0）
+func (t *csvTable) getInt(row []string, names ...string) int {
+	n, _ := strconv.Atoi(t.get(row, names...))
+	return n
+}
+
+// parseDate 解析导出文件中常见的日期格式（按本地时区）
+func parseDate(value string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02", "Mon Jan 2 2006"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
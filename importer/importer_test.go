@@ -0,0 +1,128 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		input  string
+		want   []Entry
+	}{
+		{"imdb ratings", "imdb-ratings",
+			"\ufeffConst,Your Rating,Date Rated,Title,URL,Title Type,IMDb Rating,Runtime (mins),Year\n" +
+				"tt0137523,9,2023-05-01,Fight Club,https://www.imdb.com/title/tt0137523/,Movie,8.8,139,1999\n" +
+				"tt0903747,10,2023-06-02,Breaking Bad,https://www.imdb.com/title/tt0903747/,TV Series,9.5,49,2008\n" +
+				"tt2301451,10,2023-06-03,Ozymandias,https://www.imdb.com/title/tt2301451/,TV Episode,10,47,2013\n" +
+				"tt0000001,6,2023-06-04,Some Game,https://www.imdb.com/title/tt0000001/,Video Game,7,,2020\n",
+			[]Entry{
+				{Line: 2, Kind: "movie", Title: "Fight Club", Year: 1999, IMDB: "tt0137523", Rating: 9, RatedAt: date(2023, 5, 1)},
+				{Line: 3, Kind: "show", Title: "Breaking Bad", Year: 2008, IMDB: "tt0903747", Rating: 10, RatedAt: date(2023, 6, 2)},
+				{Line: 4, Kind: "episode", Title: "Ozymandias", Year: 2013, IMDB: "tt2301451", Rating: 10, RatedAt: date(2023, 6, 3)},
+			}},
+		{"imdb watchlist", "imdb-watchlist",
+			"Position,Const,Created,Modified,Description,Title,URL,Title Type,Year\n" +
+				"1,tt1375666,2023-01-01,2023-01-01,,Inception,https://www.imdb.com/title/tt1375666/,movie,2010\n" +
+				"2,tt5753856,2023-01-02,2023-01-02,,Dark,https://www.imdb.com/title/tt5753856/,tvSeries,2017\n",
+			[]Entry{
+				{Line: 2, Kind: "movie", Title: "Inception", Year: 2010, IMDB: "tt1375666", Watchlist: true},
+				{Line: 3, Kind: "show", Title: "Dark", Year: 2017, IMDB: "tt5753856", Watchlist: true},
+			}},
+		{"letterboxd diary", "letterboxd",
+			"Date,Name,Year,Letterboxd URI,Rating,Rewatch,Tags,Watched Date\n" +
+				"2023-03-02,Parasite,2019,https://boxd.it/abc,4.5,,,2023-03-01\n" +
+				"2023-03-05,\"Crouching Tiger, Hidden Dragon\",2000,https://boxd.it/def,,Yes,,2023-03-04\n" +
+				"2023-03-06,Cats,2019,https://boxd.it/ghi,0.5,,,2023-03-06\n",
+			[]Entry{
+				{Line: 2, Kind: "movie", Title: "Parasite", Year: 2019, WatchedAt: date(2023, 3, 1), Rating: 9, RatedAt: date(2023, 3, 1)},
+				{Line: 3, Kind: "movie", Title: "Crouching Tiger, Hidden Dragon", Year: 2000, WatchedAt: date(2023, 3, 4)},
+				{Line: 4, Kind: "movie", Title: "Cats", Year: 2019, WatchedAt: date(2023, 3, 6), Rating: 1, RatedAt: date(2023, 3, 6)},
+			}},
+		{"tvtime csv", "tvtime",
+			"episode_id,tv_show_name,tv_show_id,episode_season_number,episode_number,created_at\n" +
+				"4639433,Breaking Bad,81189,5,14,2023-04-01 21:30:00\n" +
+				",Show Name,12345,0,1,2023-04-02 20:00:00\n",
+			[]Entry{
+				{Line: 2, Kind: "episode", Title: "Breaking Bad", TVDB: 4639433, ShowTVDB: 81189, Season: 5, Episode: 14,
+					WatchedAt: time.Date(2023, 4, 1, 21, 30, 0, 0, time.Local)},
+				{Line: 3, Kind: "episode", Title: "Show Name", ShowTVDB: 12345, Season: 0, Episode: 1,
+					WatchedAt: time.Date(2023, 4, 2, 20, 0, 0, 0, time.Local)},
+			}},
+		{"tvtime json", "tvtime",
+			` [{"episode_id": 4639433, "show_name": "Breaking Bad", "show_id": "81189", "season_number": 5, "number": 14, "watched_at": "2023-04-01T21:30:00Z"}]`,
+			[]Entry{
+				{Line: 1, Kind: "episode", Title: "Breaking Bad", TVDB: 4639433, ShowTVDB: 81189, Season: 5, Episode: 14,
+					WatchedAt: time.Date(2023, 4, 1, 21, 30, 0, 0, time.UTC)},
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.source, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !equalEntry(got[i], tt.want[i]) {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// equalEntry 比较记录（时间按时刻比较，不比较时区）
+func equalEntry(a, b Entry) bool {
+	if !a.WatchedAt.Equal(b.WatchedAt) || !a.RatedAt.Equal(b.RatedAt) {
+		return false
+	}
+	a.WatchedAt, b.WatchedAt, a.RatedAt, b.RatedAt = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+	return a == b
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		source, input string
+	}{
+		{"imdb-ratings", "Title,Year\nFight Club,1999\n"},
+		{"imdb-watchlist", "Title,Year\nFight Club,1999\n"},
+		{"letterboxd", "Name,Year\nParasite,2019\n"},
+		{"tvtime", "show,watched\nBreaking Bad,yes\n"},
+		{"tvtime", "[{broken"},
+		{"imdb-ratings", ""},
+		{"netflix", "Title,Date\n"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.source, strings.NewReader(tt.input)); err == nil {
+			t.Errorf("Parse(%q, %q): want error", tt.source, tt.input)
+		}
+	}
+}
+
+func TestEntryKey(t *testing.T) {
+	a := Entry{Line: 2, Kind: "movie", Title: "Parasite", Year: 2019, WatchedAt: date(2023, 3, 1), Rating: 9}
+	moved := a
+	moved.Line = 10
+	if a.Key() != moved.Key() {
+		t.Error("key should not depend on the line number")
+	}
+	for _, other := range []Entry{
+		{Line: 2, Kind: "movie", Title: "Cats", Year: 2019, WatchedAt: date(2023, 3, 1), Rating: 9},
+		{Line: 2, Kind: "movie", Title: "Parasite", Year: 2019, WatchedAt: date(2023, 3, 2), Rating: 9},
+		{Line: 2, Kind: "movie", Title: "Parasite", Year: 2019, WatchedAt: date(2023, 3, 1), Rating: 8},
+	} {
+		if a.Key() == other.Key() {
+			t.Errorf("key of %+v should differ from %+v", other, a)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseLetterboxdDiary 解析 Letterboxd 日记导出（diary.csv：Date、Name、Year、Rating、Rewatch、Watched Date 等列）
+// Letterboxd 评分为 0.5-5 星，导入时换算为 1-10 分
+func ParseLetterboxdDiary(r io.Reader) ([]Entry, error) {
+	table, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if !table.has("Name") || !table.has("Watched Date") {
+		return nil, fmt.Errorf("不是有效的 Letterboxd 日记导出文件（缺少 Name 或 Watched Date 列）")
+	}
+
+	var entries []Entry
+	for i, row := range table.rows {
+		watchedAt := parseDate(table.get(row, "Watched Date"))
+		entry := Entry{
+			Line:      i + 2,
+			Kind:      "movie",
+			Title:     table.get(row, "Name"),
+			Year:      table.getInt(row, "Year"),
+			WatchedAt: watchedAt,
+		}
+		if stars, err := strconv.ParseFloat(strings.TrimSpace(table.get(row, "Rating")), 64); err == nil && stars > 0 {
+			entry.Rating = int(math.Round(stars * 2))
+			entry.RatedAt = watchedAt
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
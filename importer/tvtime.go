@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ParseTVTime 解析 TV Time 数据导出中的已看单集记录
+// 支持 CSV（seen_episode.csv）和 JSON 数组两种格式，列名兼容新旧版本导出
+func ParseTVTime(r io.Reader) ([]Entry, error) {
+	br := bufio.NewReader(r)
+	// 跳过开头的空白后按第一个字符判断格式
+	for {
+		head, err := br.Peek(1)
+		if err != nil {
+			break
+		}
+		if len(bytes.TrimSpace(head)) > 0 {
+			if head[0] == '[' {
+				return parseTVTimeJSON(br)
+			}
+			break
+		}
+		br.ReadByte()
+	}
+
+	table, err := readCSV(br)
+	if err != nil {
+		return nil, err
+	}
+	if !table.has("episode_id") && !table.has("episode_number") {
+		return nil, fmt.Errorf("不是有效的 TV Time 导出文件（缺少 episode_id 或 episode_number 列）")
+	}
+
+	var entries []Entry
+	for i, row := range table.rows {
+		entries = append(entries, Entry{
+			Line:      i + 2,
+			Kind:      "episode",
+			Title:     table.get(row, "tv_show_name", "show_name", "series_name"),
+			TVDB:      table.getInt(row, "episode_id", "tvdb_episode_id"),
+			ShowTVDB:  table.getInt(row, "tv_show_id", "show_id", "series_id"),
+			Season:    table.getInt(row, "episode_season_number", "season_number", "season"),
+			Episode:   table.getInt(row, "episode_number", "number"),
+			WatchedAt: parseDate(table.get(row, "created_at", "watched_at", "updated_at")),
+		})
+	}
+	return entries, nil
+}
+
+// parseTVTimeJSON 解析 JSON 格式的 TV Time 导出（对象数组，字段名与 CSV 列名一致）
+func parseTVTimeJSON(r io.Reader) ([]Entry, error) {
+	var records []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("解析 TV Time JSON 失败：%v", err)
+	}
+
+	str := func(record map[string]interface{}, keys ...string) string {
+		for _, key := range keys {
+			switch v := record[key].(type) {
+			case string:
+				return v
+			case float64:
+				return strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		return ""
+	}
+	num := func(record map[string]interface{}, keys ...string) int {
+		n, _ := strconv.Atoi(str(record, keys...))
+		return n
+	}
+
+	var entries []Entry
+	for i, record := range records {
+		entries = append(entries, Entry{
+			Line:      i + 1,
+			Kind:      "episode",
+			Title:     str(record, "tv_show_name", "show_name", "series_name"),
+			TVDB:      num(record, "episode_id", "tvdb_episode_id"),
+			ShowTVDB:  num(record, "tv_show_id", "show_id", "series_id"),
+			Season:    num(record, "episode_season_number", "season_number", "season"),
+			Episode:   num(record, "episode_number", "number"),
+			WatchedAt: parseDate(str(record, "created_at", "watched_at", "updated_at")),
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// imdbIDPattern IMDb ID 格式（如 tt0903747）
+var imdbIDPattern = regexp.MustCompile(`^tt\d+$`)
+
+// itemFlags 定位条目的通用命令行参数
+type itemFlags struct {
+	kind    *string
+	season  *int
+	episode *int
+}
+
+// addItemFlags 为命令注册定位条目的通用参数
+func addItemFlags(fs *flag.FlagSet) *itemFlags {
+	return &itemFlags{
+		kind:    fs.String("type", "movie", "条目类型：movie、show、season、episode"),
+		season:  fs.Int("season", -1, "季号（type 为 season/episode 时必填，0 为特别篇）"),
+		episode: fs.Int("episode", 0, "集号（type 为 episode 时必填）"),
+	}
+}
+
+// resolve 将命令行中的条目引用解析为 sync 请求条目
+func (f *itemFlags) resolve(refs []string) (trakt.SyncItems, error) {
+	var items trakt.SyncItems
+	if len(refs) == 0 {
+		return items, fmt.Errorf("请至少指定一个条目（ID或搜索关键词）")
+	}
+	for _, ref := range refs {
+		resolved, err := resolveItem(*f.kind, ref, *f.season, *f.episode)
+		if err != nil {
+			return items, err
+		}
+		items.Add(resolved)
+	}
+	return items, nil
+}
+
+// resolveItem 解析单个条目引用
+// ref 支持：tt开头的IMDb ID、tmdb:123、tvdb:123、trakt:123、slug:xxx，其余（包括纯数字，如「1917」）按关键词搜索
+func resolveItem(kind, ref string, season, episode int) (trakt.SyncItems, error) {
+	var items trakt.SyncItems
+	searchType := kind
+	if kind == "season" || kind == "episode" {
+		searchType = "show"
+	}
+	if searchType != "movie" && searchType != "show" {
+		return items, fmt.Errorf("不支持的条目类型：%s", kind)
+	}
+
+	ids, err := resolveIDs(searchType, ref)
+	if err != nil {
+		return items, err
+	}
+
+	switch kind {
+	case "movie":
+		items.Movies = []trakt.SyncItem{{IDs: ids}}
+	case "show":
+		items.Shows = []trakt.SyncItem{{IDs: ids}}
+	case "season":
+		if season < 0 {
+			return items, fmt.Errorf("请通过 -season 指定季号")
+		}
+		items.Shows = []trakt.SyncItem{{IDs: ids, Seasons: []trakt.SyncSeason{{Number: season}}}}
+	case "episode":
+		if season < 0 || episode <= 0 {
+			return items, fmt.Errorf("请通过 -season 和 -episode 指定季号与集号")
+		}
+		items.Shows = []trakt.SyncItem{{IDs: ids, Seasons: []trakt.SyncSeason{{
+			Number:   season,
+			Episodes: []trakt.SyncEpisode{{Number: episode}},
+		}}}}
+	}
+	return items, nil
+}
+
+// resolveIDs 将ID或搜索关键词解析为电影/剧集的ID
+func resolveIDs(itemType, ref string) (trakt.TraktIDs, error) {
+	var ids trakt.TraktIDs
+	if idType, id, ok := parseIDRef(ref); ok {
+		switch idType {
+		case "imdb":
+			ids.IMDB = id
+			return ids, nil
+		case "slug":
+			ids.Slug = id
+			return ids, nil
+		}
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return ids, fmt.Errorf("无效的%s ID：%s", idType, id)
+		}
+		switch idType {
+		case "trakt":
+			ids.Trakt = n
+		case "tmdb":
+			ids.TMDb = n
+		case "tvdb":
+			ids.TVDB = n
+		}
+		return ids, nil
+	}
+
+	// 按关键词搜索
+	results, err := trakt.Search(accessToken, []string{itemType}, ref, trakt.SearchOptions{})
+	if err != nil {
+		return ids, err
+	}
+	result, err := chooseSearchResult(ref, results)
+	if err != nil {
+		return ids, err
+	}
+	return result.IDs(), nil
+}
+
+// parseIDRef 解析条目引用中的ID（tt开头为IMDb ID，或 tmdb:/tvdb:/trakt:/slug: 前缀），不是ID时 ok 为 false
+// 纯数字不视为ID，以便按「1917」「2012」等标题搜索
+func parseIDRef(ref string) (idType, id string, ok bool) {
+	if imdbIDPattern.MatchString(ref) {
+		return "imdb", ref, true
+	}
+	if prefix, value, found := strings.Cut(ref, ":"); found {
+		switch prefix {
+		case "trakt", "tmdb", "tvdb", "slug":
+			return prefix, value, true
+		}
+	}
+	return "", "", false
+}
+
+// chooseSearchResult 从搜索结果中选择条目（唯一或标题完全匹配时直接使用，否则提示用户选择）
+func chooseSearchResult(query string, results []trakt.TraktSearchResult) (*trakt.TraktSearchResult, error) {
+	if len(results) == 0 {
+		return nil, fmt.Errorf("未找到与「%s」匹配的条目", query)
+	}
+	if len(results) == 1 {
+		return &results[0], nil
+	}
+
+	var exact []int
+	for i := range results {
+		if strings.EqualFold(results[i].Title(), query) {
+			exact = append(exact, i)
+		}
+	}
+	if len(exact) == 1 {
+		return &results[exact[0]], nil
+	}
+
+	if len(results) > 5 {
+		results = results[:5]
+	}
+	fmt.Printf("「%s」匹配到多个条目：\n", query)
+	for i := range results {
+		fmt.Printf("  %d. %s (%d) [%s]\n", i+1, results[i].Title(), results[i].Year(), results[i].Type)
+	}
+	fmt.Print("请输入序号选择（直接回车选择第1个）：")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return &results[0], nil
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(results) {
+		return nil, fmt.Errorf("无效的序号：%s", line)
+	}
+	return &results[n-1], nil
+}
@@ -0,0 +1,82 @@
+package library
+
+import (
+	"encoding/xml"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// NFO Kodi 风格 NFO 文件中的条目信息
+type NFO struct {
+	Title   string
+	Year    int
+	Season  int
+	Episode int
+	IDs     trakt.TraktIDs
+}
+
+// nfoDocument movie.nfo、tvshow.nfo、单集 NFO 的通用结构（根元素名称不同，字段基本一致）
+type nfoDocument struct {
+	Title     string `xml:"title"`
+	Year      int    `xml:"year"`
+	Season    int    `xml:"season"`
+	Episode   int    `xml:"episode"`
+	IMDBID    string `xml:"imdbid"`
+	TMDbID    string `xml:"tmdbid"`
+	TVDBID    string `xml:"tvdbid"`
+	UniqueIDs []struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"uniqueid"`
+}
+
+var (
+	imdbURLPattern = regexp.MustCompile(`imdb\.com/title/(tt\d+)`)
+	tmdbURLPattern = regexp.MustCompile(`themoviedb\.org/(?:movie|tv)/(\d+)`)
+	tvdbURLPattern = regexp.MustCompile(`thetvdb\.com/.*?(?:id=|series/)(\d+)`)
+)
+
+// ReadNFO 读取 NFO 文件（XML 格式；也支持只包含 IMDb/TMDb/TVDB 链接的文本 NFO）
+func ReadNFO(path string) (*NFO, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nfo NFO
+	var doc nfoDocument
+	if err := xml.Unmarshal(data, &doc); err == nil {
+		nfo = NFO{Title: doc.Title, Year: doc.Year, Season: doc.Season, Episode: doc.Episode}
+		nfo.IDs.IMDB = doc.IMDBID
+		nfo.IDs.TMDb, _ = strconv.Atoi(doc.TMDbID)
+		nfo.IDs.TVDB, _ = strconv.Atoi(doc.TVDBID)
+		for _, id := range doc.UniqueIDs {
+			value := strings.TrimSpace(id.Value)
+			switch strings.ToLower(id.Type) {
+			case "imdb":
+				nfo.IDs.IMDB = value
+			case "tmdb":
+				nfo.IDs.TMDb, _ = strconv.Atoi(value)
+			case "tvdb":
+				nfo.IDs.TVDB, _ = strconv.Atoi(value)
+			}
+		}
+	}
+
+	// XML 之后可能附带链接（Kodi 的混合格式），纯文本 NFO 只有链接
+	text := string(data)
+	if m := imdbURLPattern.FindStringSubmatch(text); m != nil && nfo.IDs.IMDB == "" {
+		nfo.IDs.IMDB = m[1]
+	}
+	if m := tmdbURLPattern.FindStringSubmatch(text); m != nil && nfo.IDs.TMDb == 0 {
+		nfo.IDs.TMDb, _ = strconv.Atoi(m[1])
+	}
+	if m := tvdbURLPattern.FindStringSubmatch(text); m != nil && nfo.IDs.TVDB == 0 {
+		nfo.IDs.TVDB, _ = strconv.Atoi(m[1])
+	}
+	return &nfo, nil
+}
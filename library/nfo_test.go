@@ -0,0 +1,61 @@
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"traktshow/trakt"
+)
+
+func TestReadNFO(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    NFO
+	}{
+		{"movie with uniqueid", `<?xml version="1.0" encoding="UTF-8" standalone="yes" ?>
+<movie>
+  <title>Fight Club</title>
+  <year>1999</year>
+  <uniqueid type="imdb" default="true">tt0137523</uniqueid>
+  <uniqueid type="tmdb">550</uniqueid>
+</movie>`, NFO{Title: "Fight Club", Year: 1999, IDs: trakt.TraktIDs{IMDB: "tt0137523", TMDb: 550}}},
+		{"tvshow with legacy id elements", `<tvshow>
+  <title>Breaking Bad</title>
+  <year>2008</year>
+  <tvdbid>81189</tvdbid>
+  <imdbid>tt0903747</imdbid>
+</tvshow>`, NFO{Title: "Breaking Bad", Year: 2008, IDs: trakt.TraktIDs{IMDB: "tt0903747", TVDB: 81189}}},
+		{"episode", `<episodedetails>
+  <title>Ozymandias</title>
+  <season>5</season>
+  <episode>14</episode>
+  <uniqueid type="tvdb">4639433</uniqueid>
+</episodedetails>`, NFO{Title: "Ozymandias", Season: 5, Episode: 14, IDs: trakt.TraktIDs{TVDB: 4639433}}},
+		{"xml followed by url", `<movie><title>Fight Club</title></movie>
+https://www.themoviedb.org/movie/550-fight-club`, NFO{Title: "Fight Club", IDs: trakt.TraktIDs{TMDb: 550}}},
+		{"text with urls", "https://www.imdb.com/title/tt0137523/\nhttps://thetvdb.com/?tab=series&id=81189\n",
+			NFO{IDs: trakt.TraktIDs{IMDB: "tt0137523", TVDB: 81189}}},
+	}
+	dir := t.TempDir()
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+i))+".nfo")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadNFO(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("ReadNFO() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ReadNFO(filepath.Join(dir, "missing.nfo")); err == nil {
+		t.Error("missing file: want error")
+	}
+}
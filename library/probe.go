@@ -0,0 +1,207 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// ffprobeOutput ffprobe -show_streams 的输出（只包含需要的字段）
+type ffprobeOutput struct {
+	Streams []struct {
+		CodecType     string `json:"codec_type"`
+		CodecName     string `json:"codec_name"`
+		Profile       string `json:"profile"`
+		Width         int    `json:"width"`
+		Height        int    `json:"height"`
+		Channels      int    `json:"channels"`
+		ColorTransfer string `json:"color_transfer"`
+		SideDataList  []struct {
+			SideDataType string `json:"side_data_type"`
+		} `json:"side_data_list"`
+	} `json:"streams"`
+}
+
+// 文件名中的 HDR 与音频标签（无法读取容器信息时使用）
+var (
+	dolbyVisionPattern = regexp.MustCompile(`(?i)\b(?:dv|dovi|dolby[\s._-]?vision)\b`)
+	hdr10PlusPattern   = regexp.MustCompile(`(?i)\bhdr10(?:\+|plus)`)
+	hdrPattern         = regexp.MustCompile(`(?i)\bhdr(?:10)?\b`)
+	channelsPattern    = regexp.MustCompile(`(?:^|[^\d])([1257])[\s._]([01])(?:[^\d]|$)`) // 需要分隔符，避免把 HDR10 识别为 1.0
+	audioTagPatterns   = []struct {
+		pattern *regexp.Regexp
+		audio   string
+	}{
+		{regexp.MustCompile(`(?i)\batmos\b`), "dolby_atmos"},
+		{regexp.MustCompile(`(?i)\btruehd\b`), "dolby_truehd"},
+		{regexp.MustCompile(`(?i)\bdts[\s._-]?x\b`), "dts_x"},
+		{regexp.MustCompile(`(?i)\bdts[\s._-]?hd[\s._-]?ma\b`), "dts_ma"},
+		{regexp.MustCompile(`(?i)\bdts\b`), "dts"},
+		{regexp.MustCompile(`(?i)\b(?:ddp|dd\+|e-?ac-?3)`), "dolby_digital_plus"},
+		{regexp.MustCompile(`(?i)\b(?:dd|ac-?3)[\s._]?[25]`), "dolby_digital"},
+		{regexp.MustCompile(`(?i)\baac`), "aac"},
+		{regexp.MustCompile(`(?i)\bflac\b`), "flac"},
+	}
+)
+
+// Probe 获取文件的媒体信息（已安装 ffprobe 时读取容器信息，否则根据文件名标签推断）
+func Probe(path string) trakt.MediaMetadata {
+	if meta, err := probeFFprobe(path); err == nil {
+		return meta
+	}
+	return metadataFromName(path)
+}
+
+// probeFFprobe 通过 ffprobe 读取视频与首条音轨信息
+func probeFFprobe(path string) (trakt.MediaMetadata, error) {
+	var meta trakt.MediaMetadata
+	bin, err := exec.LookPath("ffprobe")
+	if err != nil {
+		return meta, err
+	}
+	out, err := exec.Command(bin, "-v", "quiet", "-print_format", "json", "-show_streams", path).Output()
+	if err != nil {
+		return meta, fmt.Errorf("ffprobe 执行失败：%v", err)
+	}
+	var probe ffprobeOutput
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return meta, fmt.Errorf("解析 ffprobe 输出失败：%v", err)
+	}
+
+	meta.MediaType = "digital"
+	audioFound := false
+	for _, s := range probe.Streams {
+		switch {
+		case s.CodecType == "video" && meta.Resolution == "" && s.Height > 0:
+			meta.Resolution = resolutionName(s.Width, s.Height)
+			switch s.ColorTransfer {
+			case "smpte2084":
+				meta.HDR = "hdr10"
+			case "arib-std-b67":
+				meta.HDR = "hlg"
+			}
+			for _, sd := range s.SideDataList {
+				if strings.Contains(strings.ToLower(sd.SideDataType), "dovi") {
+					meta.HDR = "dolby_vision"
+				}
+			}
+		case s.CodecType == "audio" && !audioFound:
+			audioFound = true
+			meta.Audio = audioName(s.CodecName, s.Profile)
+			meta.AudioChannels = channelLayout(s.Channels)
+		}
+	}
+	return meta, nil
+}
+
+// metadataFromName 根据文件名中的标签推断媒体信息
+func metadataFromName(path string) trakt.MediaMetadata {
+	meta := trakt.MediaMetadata{MediaType: "digital"}
+	switch resolutionPattern.FindString(strings.ToLower(path)) {
+	case "2160p", "4k", "uhd":
+		meta.Resolution = "uhd_4k"
+	case "1080p":
+		meta.Resolution = "hd_1080p"
+	case "720p":
+		meta.Resolution = "hd_720p"
+	case "576p":
+		meta.Resolution = "sd_576p"
+	case "480p":
+		meta.Resolution = "sd_480p"
+	}
+
+	switch {
+	case dolbyVisionPattern.MatchString(path):
+		meta.HDR = "dolby_vision"
+	case hdr10PlusPattern.MatchString(path):
+		meta.HDR = "hdr10_plus"
+	case hdrPattern.MatchString(path):
+		meta.HDR = "hdr10"
+	}
+	for _, tag := range audioTagPatterns {
+		if tag.pattern.MatchString(path) {
+			meta.Audio = tag.audio
+			break
+		}
+	}
+	if m := channelsPattern.FindStringSubmatch(path); m != nil && meta.Audio != "" {
+		meta.AudioChannels = m[1] + "." + m[2]
+	}
+	return meta
+}
+
+var resolutionPattern = regexp.MustCompile(`\b(2160p|1080p|720p|576p|480p|4k|uhd)\b`)
+
+// resolutionName 按画面尺寸换算为 Trakt 的分辨率名称（宽银幕影片高度较小，同时参考宽度）
+func resolutionName(width, height int) string {
+	switch {
+	case width >= 3200 || height >= 1800:
+		return "uhd_4k"
+	case width >= 1700 || height >= 1000:
+		return "hd_1080p"
+	case width >= 1200 || height >= 700:
+		return "hd_720p"
+	case height >= 560:
+		return "sd_576p"
+	default:
+		return "sd_480p"
+	}
+}
+
+// audioName 将 ffprobe 的音频编码换算为 Trakt 的音频名称
+func audioName(codec, profile string) string {
+	profile = strings.ToLower(profile)
+	switch codec {
+	case "truehd":
+		if strings.Contains(profile, "atmos") {
+			return "dolby_atmos"
+		}
+		return "dolby_truehd"
+	case "eac3":
+		if strings.Contains(profile, "atmos") {
+			return "dolby_digital_plus_atmos"
+		}
+		return "dolby_digital_plus"
+	case "ac3":
+		return "dolby_digital"
+	case "dts":
+		switch {
+		case strings.Contains(profile, "dts:x"):
+			return "dts_x"
+		case strings.Contains(profile, "ma"):
+			return "dts_ma"
+		}
+		return "dts"
+	case "aac", "flac", "mp3", "mp2":
+		return codec
+	case "vorbis":
+		return "ogg"
+	case "opus":
+		return "ogg_opus"
+	}
+	if strings.HasPrefix(codec, "pcm") {
+		return "lpcm"
+	}
+	return ""
+}
+
+// channelLayout 将声道数换算为 Trakt 的声道名称
+func channelLayout(channels int) string {
+	switch channels {
+	case 0:
+		return ""
+	case 1:
+		return "1.0"
+	case 2:
+		return "2.0"
+	case 6:
+		return "5.1"
+	case 8:
+		return "7.1"
+	}
+	return fmt.Sprintf("%d.0", channels)
+}
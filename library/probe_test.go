@@ -0,0 +1,58 @@
+package library
+
+import (
+	"testing"
+
+	"traktshow/trakt"
+)
+
+func TestMetadataFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want trakt.MediaMetadata
+	}{
+		{"Movie.2019.2160p.UHD.BluRay.DV.HDR10.TrueHD.Atmos.7.1-GRP.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "uhd_4k", HDR: "dolby_vision", Audio: "dolby_atmos", AudioChannels: "7.1"}},
+		{"Movie.2160p.HDR10+.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "uhd_4k", HDR: "hdr10_plus"}},
+		{"Movie.2160p.WEB-DL.HDR10.DDP5.1-GRP.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "uhd_4k", HDR: "hdr10", Audio: "dolby_digital_plus", AudioChannels: "5.1"}},
+		{"Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GRP.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "hd_1080p", Audio: "dolby_digital_plus", AudioChannels: "5.1"}},
+		{"Movie.1080p.BluRay.DTS-HD.MA.5.1-GRP.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "hd_1080p", Audio: "dts_ma", AudioChannels: "5.1"}},
+		{"Movie.720p.HDTV.x264.AAC2.0-GRP.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "hd_720p", Audio: "aac", AudioChannels: "2.0"}},
+		// 没有音频编码时不记录声道
+		{"Movie.1080p.5.1.mkv",
+			trakt.MediaMetadata{MediaType: "digital", Resolution: "hd_1080p"}},
+		{"movie.dvdrip.mkv",
+			trakt.MediaMetadata{MediaType: "digital"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metadataFromName(tt.name); got != tt.want {
+				t.Errorf("metadataFromName() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolutionName(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          string
+	}{
+		{3840, 2160, "uhd_4k"},
+		{3840, 1600, "uhd_4k"},
+		{1920, 800, "hd_1080p"},
+		{1280, 720, "hd_720p"},
+		{720, 576, "sd_576p"},
+		{720, 480, "sd_480p"},
+	}
+	for _, tt := range tests {
+		if got := resolutionName(tt.width, tt.height); got != tt.want {
+			t.Errorf("resolutionName(%d, %d) = %s, want %s", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
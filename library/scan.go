@@ -0,0 +1,151 @@
+package library
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"traktshow/release"
+	"traktshow/trakt"
+)
+
+// File 扫描到的视频文件
+type File struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+	Info    release.Info // 文件名（或所在目录名）的解析结果
+	NFO     *NFO         // 同名 NFO 或 movie.nfo（没有时为 nil）
+	ShowNFO *NFO         // 剧集目录中的 tvshow.nfo（没有时为 nil）
+}
+
+// Scan 遍历媒体目录，收集视频文件及其 NFO 信息（忽略 sample 文件与隐藏目录）
+func Scan(root string) ([]File, error) {
+	var files []File
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if strings.HasPrefix(name, ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !release.VideoExtensions[strings.ToLower(filepath.Ext(name))] || strings.Contains(strings.ToLower(name), "sample") {
+			return nil
+		}
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		file := File{Path: path, Size: stat.Size(), ModTime: stat.ModTime(), Info: parsePath(path)}
+		file.NFO = findNFO(path)
+		if file.Info.Kind == "episode" {
+			file.ShowNFO = findShowNFO(path, root)
+		}
+		files = append(files, file)
+		return nil
+	})
+	return files, err
+}
+
+// parsePath 解析文件名；电影文件名缺少年份或剧集文件名缺少剧名时参考所在目录名（如 Movie (2019)/movie.mkv、Show/Season 1/S01E02.mkv）
+func parsePath(path string) release.Info {
+	info := release.Parse(path)
+	dir := filepath.Dir(path)
+	switch {
+	case info.Kind == "movie" && info.Year == 0:
+		if parent := release.Parse(filepath.Base(dir)); parent.Kind == "movie" && parent.Year > 0 {
+			info.Title, info.Year = parent.Title, parent.Year
+		}
+	case info.Kind == "episode" && info.Title == "":
+		if strings.HasPrefix(strings.ToLower(filepath.Base(dir)), "season") || strings.HasPrefix(strings.ToLower(filepath.Base(dir)), "specials") {
+			dir = filepath.Dir(dir)
+		}
+		parent := release.Parse(filepath.Base(dir) + ".mkv")
+		info.Title, info.Year = parent.Title, parent.Year
+	}
+	return info
+}
+
+// findNFO 查找视频文件对应的 NFO（同名 .nfo 优先，其次为同目录的 movie.nfo）
+func findNFO(path string) *NFO {
+	candidates := []string{
+		strings.TrimSuffix(path, filepath.Ext(path)) + ".nfo",
+		filepath.Join(filepath.Dir(path), "movie.nfo"),
+	}
+	for _, p := range candidates {
+		if nfo, err := ReadNFO(p); err == nil {
+			return nfo
+		}
+	}
+	return nil
+}
+
+// findShowNFO 自文件所在目录向上查找 tvshow.nfo（不超出扫描根目录）
+func findShowNFO(path, root string) *NFO {
+	root = filepath.Clean(root)
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if nfo, err := ReadNFO(filepath.Join(dir, "tvshow.nfo")); err == nil {
+			return nfo
+		}
+		if dir == root || dir == filepath.Dir(dir) {
+			return nil
+		}
+	}
+}
+
+// Entry 已识别的本地条目（电影为电影ID，单集为所属剧集ID + 季号/集号）
+type Entry struct {
+	Path     string              `json:"path,omitempty"` // 本地文件路径（收藏中独有的条目为空）
+	Kind     string              `json:"kind"`           // movie、episode
+	Title    string              `json:"title"`
+	Year     int                 `json:"year,omitempty"`
+	IDs      trakt.TraktIDs      `json:"ids"`
+	Season   int                 `json:"season,omitempty"`
+	Episode  int                 `json:"episode,omitempty"`
+	Metadata trakt.MediaMetadata `json:"metadata"`
+}
+
+// Identify 识别文件对应的 Trakt 条目（NFO 中有外部ID时直接使用，否则通过匹配器按文件名匹配）
+// 返回的置信度为1表示来自 NFO；无法匹配时返回的错误包装 release.ErrNoMatch，其余错误为请求失败
+func Identify(file *File, matcher *release.Matcher) (*Entry, float64, error) {
+	entry := &Entry{Path: file.Path, Kind: file.Info.Kind, Title: file.Info.Title, Year: file.Info.Year}
+
+	switch {
+	case file.Info.Kind == "movie" && file.NFO != nil && file.NFO.IDs != (trakt.TraktIDs{}):
+		entry.IDs = file.NFO.IDs
+		if file.NFO.Title != "" {
+			entry.Title, entry.Year = file.NFO.Title, file.NFO.Year
+		}
+		return entry, 1, nil
+	case file.Info.Kind == "episode" && file.ShowNFO != nil && file.ShowNFO.IDs != (trakt.TraktIDs{}) && file.Info.Episode > 0:
+		entry.IDs = file.ShowNFO.IDs
+		entry.Season, entry.Episode = file.Info.Season, file.Info.Episode
+		if file.ShowNFO.Title != "" {
+			entry.Title, entry.Year = file.ShowNFO.Title, file.ShowNFO.Year
+		}
+		return entry, 1, nil
+	}
+
+	match, err := matcher.Match(file.Info)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch {
+	case match.Movie != nil:
+		entry.Title, entry.Year, entry.IDs = match.Movie.Title, match.Movie.Year, match.Movie.IDs
+	case match.Show != nil && match.Episode != nil:
+		entry.Title, entry.Year, entry.IDs = match.Show.Title, match.Show.Year, match.Show.IDs
+		entry.Season, entry.Episode = match.Episode.Season, match.Episode.Number
+	default:
+		// 收藏需要确定的季号/集号
+		return nil, match.Confidence, fmt.Errorf("%w：未能定位到单集：%s", release.ErrNoMatch, match.Describe())
+	}
+	return entry, match.Confidence, nil
+}
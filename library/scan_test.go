@@ -0,0 +1,34 @@
+package library
+
+import (
+	"testing"
+
+	"traktshow/release"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want release.Info
+	}{
+		{"/media/movies/Movie (2019)/movie.mkv",
+			release.Info{Kind: "movie", Title: "Movie", Year: 2019}},
+		{"/media/movies/Movie.2019.1080p.BluRay-GRP/grp-movie-1080p.mkv",
+			release.Info{Kind: "movie", Title: "Movie", Year: 2019, Resolution: "1080p"}},
+		{"/media/movies/Movie (2019)/Movie (2019).mkv",
+			release.Info{Kind: "movie", Title: "Movie", Year: 2019}},
+		{"/media/tv/Show Name (2018)/Season 1/S01E02.mkv",
+			release.Info{Kind: "episode", Title: "Show Name", Year: 2018, Season: 1, Episode: 2}},
+		{"/media/tv/Show Name/Specials/S00E01.mkv",
+			release.Info{Kind: "episode", Title: "Show Name", Season: 0, Episode: 1}},
+		{"/media/tv/Show Name/Season 1/Show Name - S01E03 - Title.mkv",
+			release.Info{Kind: "episode", Title: "Show Name", Season: 1, Episode: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := parsePath(tt.path); got != tt.want {
+				t.Errorf("parsePath() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
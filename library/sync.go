@@ -0,0 +1,82 @@
+package library
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// Diff 比较本地条目与 Trakt 收藏，返回需要添加的本地条目与收藏中本地不存在的条目
+func Diff(local []Entry, movies []trakt.TraktCollectedMovie, shows []trakt.TraktCollectedShow) (added, missing []Entry) {
+	var remote []Entry
+	for _, m := range movies {
+		remote = append(remote, Entry{Kind: "movie", Title: m.Movie.Title, Year: m.Movie.Year, IDs: m.Movie.IDs, Metadata: m.Metadata})
+	}
+	for _, s := range shows {
+		for _, season := range s.Seasons {
+			for _, e := range season.Episodes {
+				remote = append(remote, Entry{Kind: "episode", Title: s.Show.Title, Year: s.Show.Year, IDs: s.Show.IDs,
+					Season: season.Number, Episode: e.Number, Metadata: e.Metadata})
+			}
+		}
+	}
+
+	// 同一条目可能有多个文件（不同版本），只需添加一次
+	for i := range local {
+		if !containsEntry(remote, &local[i]) && !containsEntry(added, &local[i]) {
+			added = append(added, local[i])
+		}
+	}
+	for i := range remote {
+		if !containsEntry(local, &remote[i]) {
+			missing = append(missing, remote[i])
+		}
+	}
+	return added, missing
+}
+
+// containsEntry 判断列表中是否存在同一条目
+func containsEntry(list []Entry, e *Entry) bool {
+	for i := range list {
+		if list[i].Kind == e.Kind && list[i].Season == e.Season && list[i].Episode == e.Episode && sameIDs(list[i].IDs, e.IDs) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameIDs 任一外部ID相同即视为同一条目（NFO 中通常只有 IMDb/TMDb/TVDB ID）
+func sameIDs(a, b trakt.TraktIDs) bool {
+	return a.Trakt != 0 && a.Trakt == b.Trakt ||
+		a.IMDB != "" && a.IMDB == b.IMDB ||
+		a.TMDb != 0 && a.TMDb == b.TMDb ||
+		a.TVDB != 0 && a.TVDB == b.TVDB
+}
+
+// SyncItems 将条目转换为 sync 接口的提交内容（单集按剧集分组，附带各自的媒体信息）
+func SyncItems(entries []Entry) trakt.SyncItems {
+	var items trakt.SyncItems
+	showIndex := make(map[string]int)
+	for _, e := range entries {
+		switch e.Kind {
+		case "movie":
+			items.Movies = append(items.Movies, trakt.SyncItem{IDs: e.IDs, MediaMetadata: e.Metadata})
+		case "episode":
+			key := fmt.Sprintf("%d|%s|%d|%d", e.IDs.Trakt, e.IDs.IMDB, e.IDs.TMDb, e.IDs.TVDB)
+			i, ok := showIndex[key]
+			if !ok {
+				i = len(items.Shows)
+				showIndex[key] = i
+				items.Shows = append(items.Shows, trakt.SyncItem{IDs: e.IDs})
+			}
+			show := &items.Shows[i]
+			episode := trakt.SyncEpisode{Number: e.Episode, MediaMetadata: e.Metadata}
+			if n := len(show.Seasons); n > 0 && show.Seasons[n-1].Number == e.Season {
+				show.Seasons[n-1].Episodes = append(show.Seasons[n-1].Episodes, episode)
+			} else {
+				show.Seasons = append(show.Seasons, trakt.SyncSeason{Number: e.Season, Episodes: []trakt.SyncEpisode{episode}})
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,90 @@
+package library
+
+import (
+	"encoding/json"
+	"testing"
+
+	"traktshow/trakt"
+)
+
+func TestDiff(t *testing.T) {
+	var movies []trakt.TraktCollectedMovie
+	var shows []trakt.TraktCollectedShow
+	if err := json.Unmarshal([]byte(`[
+		{"movie": {"title": "Collected Movie", "year": 2019, "ids": {"trakt": 1, "imdb": "tt0000001"}}},
+		{"movie": {"title": "Deleted Movie", "year": 2020, "ids": {"trakt": 2, "imdb": "tt0000002"}}}
+	]`), &movies); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"show": {"title": "Show Name", "year": 2018, "ids": {"trakt": 10, "tvdb": 100}},
+		 "seasons": [{"number": 1, "episodes": [{"number": 1}, {"number": 2}]}]}
+	]`), &shows); err != nil {
+		t.Fatal(err)
+	}
+
+	local := []Entry{
+		// NFO 中只有 IMDb ID，与收藏中的 Trakt ID 不同但视为同一条目
+		{Kind: "movie", Title: "Collected Movie", IDs: trakt.TraktIDs{IMDB: "tt0000001"}},
+		{Kind: "movie", Title: "New Movie", IDs: trakt.TraktIDs{TMDb: 550}},
+		// 同一电影的另一个版本只添加一次
+		{Kind: "movie", Title: "New Movie", IDs: trakt.TraktIDs{TMDb: 550}},
+		{Kind: "episode", Title: "Show Name", IDs: trakt.TraktIDs{TVDB: 100}, Season: 1, Episode: 1},
+		{Kind: "episode", Title: "Show Name", IDs: trakt.TraktIDs{TVDB: 100}, Season: 1, Episode: 3},
+	}
+	added, missing := Diff(local, movies, shows)
+
+	if len(added) != 2 || added[0].Title != "New Movie" || added[1].Episode != 3 {
+		t.Errorf("added = %+v", added)
+	}
+	if len(missing) != 2 || missing[0].Title != "Deleted Movie" ||
+		missing[1].Kind != "episode" || missing[1].Season != 1 || missing[1].Episode != 2 {
+		t.Errorf("missing = %+v", missing)
+	}
+}
+
+func TestSameIDs(t *testing.T) {
+	tests := []struct {
+		a, b trakt.TraktIDs
+		want bool
+	}{
+		{trakt.TraktIDs{Trakt: 1}, trakt.TraktIDs{Trakt: 1, IMDB: "tt1"}, true},
+		{trakt.TraktIDs{IMDB: "tt1"}, trakt.TraktIDs{Trakt: 2, IMDB: "tt1"}, true},
+		{trakt.TraktIDs{TVDB: 5}, trakt.TraktIDs{TMDb: 5}, false},
+		{trakt.TraktIDs{}, trakt.TraktIDs{}, false},
+	}
+	for _, tt := range tests {
+		if got := sameIDs(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameIDs(%+v, %+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSyncItems(t *testing.T) {
+	show := trakt.TraktIDs{TVDB: 100}
+	meta := trakt.MediaMetadata{Resolution: "hd_1080p"}
+	items := SyncItems([]Entry{
+		{Kind: "movie", IDs: trakt.TraktIDs{TMDb: 550}, Metadata: meta},
+		{Kind: "episode", IDs: show, Season: 1, Episode: 1},
+		{Kind: "episode", IDs: show, Season: 1, Episode: 2, Metadata: meta},
+		{Kind: "episode", IDs: show, Season: 2, Episode: 1},
+		{Kind: "episode", IDs: trakt.TraktIDs{TVDB: 200}, Season: 1, Episode: 1},
+	})
+
+	if len(items.Movies) != 1 || items.Movies[0].IDs.TMDb != 550 || items.Movies[0].Resolution != "hd_1080p" {
+		t.Errorf("movies = %+v", items.Movies)
+	}
+	if len(items.Shows) != 2 {
+		t.Fatalf("shows = %+v", items.Shows)
+	}
+	seasons := items.Shows[0].Seasons
+	if items.Shows[0].IDs != show || len(seasons) != 2 || len(seasons[0].Episodes) != 2 || len(seasons[1].Episodes) != 1 {
+		t.Errorf("first show = %+v", items.Shows[0])
+	}
+	if seasons[0].Episodes[1].Number != 2 || seasons[0].Episodes[1].Resolution != "hd_1080p" {
+		t.Errorf("episode metadata = %+v", seasons[0].Episodes[1])
+	}
+	if items.Shows[1].IDs.TVDB != 200 {
+		t.Errorf("second show = %+v", items.Shows[1])
+	}
+}
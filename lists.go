@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runLists 个人列表管理（list/liked/show/create/update/delete/add/remove）
+func runLists(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	sub, args := args[0], args[1:]
+
+	switch sub {
+	case "list", "show":
+		fs := flag.NewFlagSet("lists "+sub, flag.ExitOnError)
+		user := fs.String("user", "me", "列表所属用户（默认当前用户，其他用户仅能查看公开列表）")
+		fs.Parse(args)
+
+		if sub == "list" {
+			lists, err := trakt.GetLists(accessToken, *user)
+			if err != nil {
+				return err
+			}
+			utils.PrintLists("个人列表", lists)
+			return nil
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("用法：lists show [-user 用户名] <列表ID或slug>")
+		}
+		items, err := trakt.GetListItems(accessToken, *user, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		utils.PrintListItems("列表 "+fs.Arg(0), items)
+		return nil
+
+	case "liked":
+		liked, err := trakt.GetLikedLists(accessToken)
+		if err != nil {
+			return err
+		}
+		lists := make([]trakt.TraktList, len(liked))
+		for i := range liked {
+			lists[i] = liked[i].List
+		}
+		utils.PrintLists("点赞的列表", lists)
+		return nil
+
+	case "create", "update":
+		fs := flag.NewFlagSet("lists "+sub, flag.ExitOnError)
+		name := fs.String("name", "", "新名称（仅 update）")
+		description := fs.String("description", "", "列表描述")
+		privacy := fs.String("privacy", "private", "隐私设置：private、link、friends、public")
+		displayNumbers := fs.Bool("numbers", false, "是否显示排名序号")
+		allowComments := fs.Bool("comments", true, "是否允许评论")
+		sortBy := fs.String("sort-by", "rank", "排序字段：rank、added、title、released、runtime、popularity 等")
+		sortHow := fs.String("sort-how", "asc", "排序方向：asc、desc")
+		fs.Parse(args)
+
+		if fs.NArg() != 1 {
+			return fmt.Errorf("用法：lists %s [参数] <列表名称或ID>", sub)
+		}
+
+		// 仅提交命令行中显式指定的设置（创建时名称取自参数）
+		var settings trakt.ListSettings
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "name":
+				settings.Name = name
+			case "description":
+				settings.Description = description
+			case "privacy":
+				settings.Privacy = privacy
+			case "numbers":
+				settings.DisplayNumbers = displayNumbers
+			case "comments":
+				settings.AllowComments = allowComments
+			case "sort-by":
+				settings.SortBy = sortBy
+			case "sort-how":
+				settings.SortHow = sortHow
+			}
+		})
+
+		var list *trakt.TraktList
+		var err error
+		if sub == "create" {
+			listName := fs.Arg(0)
+			settings.Name = &listName
+			list, err = trakt.CreateList(accessToken, settings)
+		} else {
+			list, err = trakt.UpdateList(accessToken, fs.Arg(0), settings)
+		}
+		if err != nil {
+			return err
+		}
+		utils.PrintLists("列表已保存", []trakt.TraktList{*list})
+		return nil
+
+	case "delete":
+		if len(args) != 1 {
+			return fmt.Errorf("用法：lists delete <列表ID或slug>")
+		}
+		if !confirm(fmt.Sprintf("确定删除列表「%s」及其全部条目？(y/N)：", args[0])) {
+			return nil
+		}
+		if err := trakt.DeleteList(accessToken, args[0]); err != nil {
+			return err
+		}
+		fmt.Println("✅ 列表已删除")
+		return nil
+
+	case "add", "remove":
+		fs := flag.NewFlagSet("lists "+sub, flag.ExitOnError)
+		target := addItemFlags(fs)
+		fs.Parse(args)
+
+		if fs.NArg() < 2 {
+			return fmt.Errorf("用法：lists %s [参数] <列表ID或slug> <条目...>", sub)
+		}
+		items, err := target.resolve(fs.Args()[1:])
+		if err != nil {
+			return err
+		}
+
+		var result *trakt.SyncResult
+		if sub == "add" {
+			result, err = trakt.AddListItems(accessToken, fs.Arg(0), items)
+		} else {
+			result, err = trakt.RemoveListItems(accessToken, fs.Arg(0), items)
+		}
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+		return nil
+	}
+	return fmt.Errorf("未知的子命令：%s（可用：list、liked、show、create、update、delete、add、remove）", sub)
+}
@@ -19,16 +19,25 @@ func main() {
 		log.Fatalf("配置初始化失败：%v", err)
 	}
 
-	// 2. 尝试加载已保存的令牌（有则直接使用）
+	// 2. 尝试加载已保存的令牌（有则直接使用，无则走手动授权流程）
 	token, err := utils.LoadToken()
 	if err == nil {
-		accessToken = token
 		log.Println("使用已保存的令牌直接查询数据...")
-		fetchAndPrintData()
+	} else {
+		token = authorize()
+	}
+	accessToken = token
+
+	// 3. 带子命令时执行对应命令，否则查询并打印用户数据
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1], os.Args[2:])
 		return
 	}
+	fetchAndPrintData()
+}
 
-	// 3. 手动授权流程（核心：无需本地服务，彻底绕开端口占用）
+// authorize 手动授权流程（核心：无需本地服务，彻底绕开端口占用）
+func authorize() *oauth2.Token {
 	log.Println("\n===== Trakt 手动授权流程 =====")
 	// 生成授权URL（回调地址已自动为 8081）
 	authURL := trakt.GetOAuthConfig().AuthCodeURL("state-random-123", oauth2.AccessTypeOffline)
@@ -48,11 +57,10 @@ func main() {
 
 	// 4. 手动交换令牌（核心步骤，无依赖本地服务）
 	log.Printf("正在交换访问令牌...（授权码：%s）", code)
-	token, err = trakt.ExchangeTokenManual(code)
+	token, err := trakt.ExchangeTokenManual(code)
 	if err != nil {
 		log.Fatalf("令牌交换失败：%v", err)
 	}
-	accessToken = token
 	log.Println("✅ 令牌交换成功！")
 
 	// 5. 保存令牌（下次无需重复授权）
@@ -61,9 +69,7 @@ func main() {
 	} else {
 		log.Println("✅ 令牌已保存，下次运行直接使用")
 	}
-
-	// 6. 查询并打印用户数据
-	fetchAndPrintData()
+	return token
 }
 
 // fetchAndPrintData 查询用户信息和观看记录并打印
@@ -82,7 +88,12 @@ func fetchAndPrintData() {
 	if err != nil {
 		log.Fatalf("❌ 获取观看记录失败：%v", err)
 	}
-	utils.PrintWatchHistory(watchHistory)
+	// 获取用户评分（失败不影响观看记录的展示）
+	ratings, err := trakt.GetRatings(accessToken, "")
+	if err != nil {
+		log.Printf("⚠️  获取评分失败：%v（不显示我的评分）", err)
+	}
+	utils.PrintWatchHistory(watchHistory, trakt.NewRatingIndex(ratings))
 
 	log.Println("\n🎉 所有数据查询完成！")
 	os.Exit(0)
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"traktshow/trakt"
+)
+
+// 请求耗时直方图的分桶（秒）
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// 路径中 ID 所在位置的前一段（用于将 /shows/123/progress 归并为 /shows/:id/progress）
+var idParents = map[string]bool{
+	"shows": true, "movies": true, "people": true, "lists": true, "comments": true,
+	"seasons": true, "episodes": true,
+}
+
+var numericSegment = regexp.MustCompile(`^\d[\d-]*$`)
+
+// requestKey 请求计数的标签
+type requestKey struct {
+	endpoint, method, code string
+}
+
+// latency 单个接口的耗时直方图
+type latency struct {
+	counts []uint64 // 与 latencyBuckets 对应的累计计数
+	count  uint64
+	sum    float64
+}
+
+// rateLimit Trakt 的限流状态（X-Ratelimit 响应头）
+type rateLimit struct {
+	Name      string `json:"name"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+}
+
+// APIMetrics Trakt 接口调用的统计（请求数、耗时、状态码、限流剩余）
+type APIMetrics struct {
+	mu         sync.Mutex
+	requests   map[requestKey]uint64
+	latencies  map[string]*latency
+	rateLimits map[string]rateLimit
+}
+
+// NewAPIMetrics 创建接口统计（通过 Observe 接收请求结果）
+func NewAPIMetrics() *APIMetrics {
+	return &APIMetrics{
+		requests:   make(map[requestKey]uint64),
+		latencies:  make(map[string]*latency),
+		rateLimits: make(map[string]rateLimit),
+	}
+}
+
+// Observe 记录一次请求（可直接赋值给 trakt.OnRequest）
+func (m *APIMetrics) Observe(info trakt.RequestInfo) {
+	endpoint := Endpoint(info.Path)
+	code := "error"
+	if info.StatusCode > 0 {
+		code = strconv.Itoa(info.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{endpoint, info.Method, code}]++
+
+	l := m.latencies[endpoint]
+	if l == nil {
+		l = &latency{counts: make([]uint64, len(latencyBuckets))}
+		m.latencies[endpoint] = l
+	}
+	seconds := info.Duration.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			l.counts[i]++
+		}
+	}
+	l.count++
+	l.sum += seconds
+
+	if header := info.Header.Get("X-Ratelimit"); header != "" {
+		var rl rateLimit
+		if err := json.Unmarshal([]byte(header), &rl); err == nil && rl.Name != "" {
+			m.rateLimits[rl.Name] = rl
+		}
+	}
+}
+
+// families 导出为指标
+func (m *APIMetrics) families() []*family {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := &family{name: "trakt_api_requests_total", help: "Trakt API requests by endpoint, method and status code.", kind: "counter"}
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.code < b.code
+	})
+	for _, k := range keys {
+		requests.add(float64(m.requests[k]), "endpoint", k.endpoint, "method", k.method, "code", k.code)
+	}
+
+	durations := &family{name: "trakt_api_request_duration_seconds", help: "Trakt API request latency.", kind: "histogram"}
+	endpoints := make([]string, 0, len(m.latencies))
+	for e := range m.latencies {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+	for _, e := range endpoints {
+		l := m.latencies[e]
+		for i, bound := range latencyBuckets {
+			durations.add(float64(l.counts[i]), "__suffix", "_bucket", "endpoint", e, "le", formatFloat(bound))
+		}
+		durations.add(float64(l.count), "__suffix", "_bucket", "endpoint", e, "le", "+Inf")
+		durations.add(l.sum, "__suffix", "_sum", "endpoint", e)
+		durations.add(float64(l.count), "__suffix", "_count", "endpoint", e)
+	}
+
+	remaining := &family{name: "trakt_api_ratelimit_remaining", help: "Remaining requests in the current Trakt rate-limit window.", kind: "gauge"}
+	limit := &family{name: "trakt_api_ratelimit_limit", help: "Request limit of the Trakt rate-limit window.", kind: "gauge"}
+	names := make([]string, 0, len(m.rateLimits))
+	for name := range m.rateLimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rl := m.rateLimits[name]
+		remaining.add(float64(rl.Remaining), "name", name)
+		limit.add(float64(rl.Limit), "name", name)
+	}
+	return []*family{requests, durations, remaining, limit}
+}
+
+// Endpoint 将请求路径归并为低基数的接口名（去除查询参数，ID、日期等替换为占位符）
+func Endpoint(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segments {
+		switch {
+		case i > 0 && idParents[segments[i-1]] && segments[0] != "calendars":
+			segments[i] = ":id"
+		case i > 0 && segments[i-1] == "users" && seg != "me" && seg != "settings" && seg != "hidden":
+			segments[i] = ":user"
+		case numericSegment.MatchString(seg):
+			segments[i] = ":n"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sample 单个样本（标签按键名排序输出）
+type sample struct {
+	labels map[string]string
+	value  float64
+}
+
+// family 同名指标（Prometheus 文本格式的一组 HELP/TYPE 与样本）
+type family struct {
+	name    string
+	help    string
+	kind    string // counter、gauge、histogram
+	samples []sample
+}
+
+// add 添加样本（labels 为键值交替的列表）
+func (f *family) add(value float64, labels ...string) {
+	s := sample{labels: make(map[string]string), value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.labels[labels[i]] = labels[i+1]
+	}
+	f.samples = append(f.samples, s)
+}
+
+// write 按 Prometheus 文本格式输出
+func (f *family) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	for _, s := range f.samples {
+		name := f.name
+		if suffix, ok := s.labels["__suffix"]; ok {
+			name += suffix
+		}
+		fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(s.labels), formatFloat(s.value))
+	}
+}
+
+// formatLabels 格式化标签（{a="1",b="2"}，没有标签时为空）
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != "__suffix" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + `="` + escapeLabel(labels[k]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// escapeLabel 转义标签值中的反斜杠、引号与换行
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// formatFloat 格式化样本值
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// Handler 以 Prometheus 文本格式输出接口统计与观看指标
+type Handler struct {
+	API     *APIMetrics
+	Viewing *Viewing
+}
+
+// ServeHTTP 输出 /metrics
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var families []*family
+	if h.Viewing != nil {
+		families = append(families, h.Viewing.families()...)
+	}
+	if h.API != nil {
+		families = append(families, h.API.families()...)
+	}
+
+	var buf bytes.Buffer
+	for _, f := range families {
+		if len(f.samples) > 0 {
+			f.write(&buf)
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(buf.Bytes())
+}
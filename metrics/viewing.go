@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// snapshot 一次刷新得到的观看数据
+type snapshot struct {
+	stats     trakt.TraktUserStats
+	watchlist map[string]int // 类型 → 条目数
+	upNext    []trakt.UpNextItem
+}
+
+// Viewing 观看习惯指标（后台定时刷新，抓取时直接输出最近一次的结果；数据与命令行共用本地缓存）
+type Viewing struct {
+	LoadToken func() (*oauth2.Token, error)
+	Interval  time.Duration // 刷新间隔（缓存超过间隔的一半时重新获取，与仪表盘等共用的缓存较新时直接使用）
+
+	mu          sync.Mutex
+	data        *snapshot
+	refreshedAt time.Time
+	errors      uint64
+}
+
+// Run 立即刷新一次，之后按间隔定时刷新（阻塞，通常在单独的 goroutine 中运行）
+func (v *Viewing) Run() {
+	for {
+		if err := v.refresh(); err != nil {
+			log.Printf("❌ 刷新观看指标失败：%v", err)
+		}
+		time.Sleep(v.Interval)
+	}
+}
+
+// refresh 获取统计、观看清单与追剧进度
+func (v *Viewing) refresh() error {
+	fail := func(err error) error {
+		v.mu.Lock()
+		v.errors++
+		v.mu.Unlock()
+		return err
+	}
+
+	token, err := v.LoadToken()
+	if err != nil {
+		return fail(err)
+	}
+	maxAge := v.Interval / 2
+	var data snapshot
+	if data.stats, err = utils.CachedWithin("stats", maxAge, func() (trakt.TraktUserStats, error) {
+		stats, err := trakt.GetUserStats(token)
+		if err != nil {
+			return trakt.TraktUserStats{}, err
+		}
+		return *stats, nil
+	}); err != nil {
+		return fail(err)
+	}
+	watchlist, err := utils.CachedWithin("watchlist", maxAge, func() ([]trakt.TraktListItem, error) {
+		return trakt.GetWatchlist(token, "", "rank")
+	})
+	if err != nil {
+		return fail(err)
+	}
+	data.watchlist = map[string]int{"movie": 0, "show": 0, "season": 0, "episode": 0}
+	for _, item := range watchlist {
+		data.watchlist[item.Type]++
+	}
+	if data.upNext, err = utils.CachedWithin("up_next", maxAge, func() ([]trakt.UpNextItem, error) {
+		return trakt.GetUpNext(token, trakt.UpNextOptions{SortBy: "last-watched"})
+	}); err != nil {
+		return fail(err)
+	}
+
+	v.mu.Lock()
+	v.data, v.refreshedAt = &data, time.Now()
+	v.mu.Unlock()
+	return nil
+}
+
+// families 导出为指标（尚未成功刷新时只输出刷新状态）
+func (v *Viewing) families() []*family {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	errors := &family{name: "trakt_metrics_refresh_errors_total", help: "Failed refreshes of viewing metrics.", kind: "counter"}
+	errors.add(float64(v.errors))
+	families := []*family{errors}
+	if v.data == nil {
+		return families
+	}
+	refreshed := &family{name: "trakt_metrics_last_refresh_timestamp_seconds", help: "Unix time of the last successful refresh of viewing metrics.", kind: "gauge"}
+	refreshed.add(float64(v.refreshedAt.Unix()))
+
+	s := &v.data.stats
+	plays := &family{name: "trakt_plays_total", help: "Total plays by media type.", kind: "counter"}
+	plays.add(float64(s.Movies.Plays), "type", "movie")
+	plays.add(float64(s.Episodes.Plays), "type", "episode")
+
+	minutes := &family{name: "trakt_watched_minutes_total", help: "Total minutes watched by media type.", kind: "counter"}
+	minutes.add(float64(s.Movies.Minutes), "type", "movie")
+	minutes.add(float64(s.Episodes.Minutes), "type", "episode")
+
+	watched := &family{name: "trakt_watched_items", help: "Distinct watched items by media type.", kind: "gauge"}
+	watched.add(float64(s.Movies.Watched), "type", "movie")
+	watched.add(float64(s.Shows.Watched), "type", "show")
+	watched.add(float64(s.Episodes.Watched), "type", "episode")
+
+	collected := &family{name: "trakt_collected_items", help: "Collected items by media type.", kind: "gauge"}
+	collected.add(float64(s.Movies.Collected), "type", "movie")
+	collected.add(float64(s.Episodes.Collected), "type", "episode")
+
+	ratings := &family{name: "trakt_ratings", help: "Number of ratings.", kind: "gauge"}
+	ratings.add(float64(s.Ratings.Total))
+
+	watchlist := &family{name: "trakt_watchlist_items", help: "Watchlist size by media type.", kind: "gauge"}
+	for _, t := range []string{"movie", "show", "season", "episode"} {
+		watchlist.add(float64(v.data.watchlist[t]), "type", t)
+	}
+
+	inProgress := &family{name: "trakt_shows_in_progress", help: "Shows started but not finished (excluding hidden and dropped).", kind: "gauge"}
+	inProgress.add(float64(len(v.data.upNext)))
+	unwatched := &family{name: "trakt_episodes_aired_unwatched", help: "Aired but unwatched episodes of in-progress shows.", kind: "gauge"}
+	total := 0
+	for _, u := range v.data.upNext {
+		total += max(u.Progress.Aired-u.Progress.Completed, 0)
+	}
+	unwatched.add(float64(total))
+
+	return append(families, refreshed, plays, minutes, watched, collected, ratings, watchlist, inProgress, unwatched)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runRatings 评分管理与分析（list/set/remove/stats/deviation）
+func runRatings(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list", "stats", "deviation":
+		fs := flag.NewFlagSet("ratings "+args[0], flag.ExitOnError)
+		itemType := fs.String("type", "", "条目类型：movies、shows、seasons、episodes（默认全部）")
+		limit := fs.Int("limit", 20, "偏差列表显示的条目数（仅 deviation）")
+		fs.Parse(args[1:])
+
+		ratings, err := trakt.GetRatings(accessToken, *itemType)
+		if err != nil {
+			return err
+		}
+		switch args[0] {
+		case "list":
+			utils.PrintRatings(ratings)
+		case "stats":
+			utils.PrintRatingDistribution(ratings)
+		case "deviation":
+			utils.PrintRatingDeviation(ratings, *limit)
+		}
+		return nil
+
+	case "set", "remove":
+		fs := flag.NewFlagSet("ratings "+args[0], flag.ExitOnError)
+		target := addItemFlags(fs)
+		rating := fs.Int("rating", 0, "评分（1-10，仅 set）")
+		fs.Parse(args[1:])
+
+		if args[0] == "set" && (*rating < 1 || *rating > 10) {
+			return fmt.Errorf("评分必须在 1-10 之间")
+		}
+		items, err := target.resolve(fs.Args())
+		if err != nil {
+			return err
+		}
+
+		var result *trakt.SyncResult
+		if args[0] == "set" {
+			items.SetRating(*rating)
+			result, err = trakt.AddRatings(accessToken, items)
+		} else {
+			result, err = trakt.RemoveRatings(accessToken, items)
+		}
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+		return nil
+	}
+	return fmt.Errorf("未知的子命令：%s（可用：list、set、remove、stats、deviation）", args[0])
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runRecommend 查看 Trakt 个性化推荐（recommend hide <ID> 隐藏推荐）
+func runRecommend(args []string) error {
+	fs := flag.NewFlagSet("recommend", flag.ExitOnError)
+	mediaType := fs.String("type", "movies", "推荐类型：movies 或 shows")
+	var opts trakt.RecommendationOptions
+	fs.BoolVar(&opts.IgnoreCollected, "ignore-collected", true, "排除已收藏的条目")
+	fs.BoolVar(&opts.IgnoreWatchlisted, "ignore-watchlisted", true, "排除已在观看清单中的条目")
+	fs.IntVar(&opts.Limit, "limit", 10, "推荐条目数")
+	pick := fs.Bool("pick", false, "列出推荐后选择条目加入观看清单或隐藏")
+
+	hide := len(args) > 0 && args[0] == "hide"
+	if hide {
+		fs.Parse(args[1:])
+	} else {
+		fs.Parse(args)
+	}
+	if *mediaType != "movies" && *mediaType != "shows" {
+		return fmt.Errorf("不支持的推荐类型：%s", *mediaType)
+	}
+
+	if hide {
+		if fs.NArg() != 1 {
+			return fmt.Errorf("用法：recommend hide [-type movies|shows] <Trakt ID、slug 或 IMDb ID>")
+		}
+		if err := trakt.HideRecommendation(accessToken, *mediaType, fs.Arg(0)); err != nil {
+			return err
+		}
+		fmt.Println("✅ 已隐藏该推荐")
+		return nil
+	}
+	items, err := trakt.GetRecommendations(accessToken, *mediaType, opts)
+	if err != nil {
+		return err
+	}
+	utils.PrintDiscover("recommendations", items, nil)
+
+	if *pick {
+		return pickRecommendations(*mediaType, items)
+	}
+	return nil
+}
+
+// pickRecommendations 交互式处理推荐：输入序号加入观看清单，输入 h序号 隐藏推荐
+func pickRecommendations(mediaType string, items []trakt.DiscoverItem) error {
+	fmt.Print("输入序号加入观看清单，h+序号隐藏推荐（空格分隔，直接回车跳过）：")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	var toAdd trakt.SyncItems
+	for _, field := range strings.Fields(line) {
+		hide := strings.HasPrefix(field, "h")
+		n, err := strconv.Atoi(strings.TrimPrefix(field, "h"))
+		if err != nil || n < 1 || n > len(items) {
+			return fmt.Errorf("无效的序号：%s", field)
+		}
+		item := items[n-1]
+
+		if hide {
+			if err := trakt.HideRecommendation(accessToken, mediaType, fmt.Sprint(item.TraktID())); err != nil {
+				return err
+			}
+			fmt.Printf("✅ 已隐藏：%s\n", utils.FormatMediaTitle(item.Movie, item.Show, nil, nil))
+			continue
+		}
+		if item.Movie != nil {
+			toAdd.Movies = append(toAdd.Movies, trakt.SyncItem{IDs: item.Movie.IDs})
+		} else if item.Show != nil {
+			toAdd.Shows = append(toAdd.Shows, trakt.SyncItem{IDs: item.Show.IDs})
+		}
+	}
+
+	if toAdd.Len() == 0 {
+		return nil
+	}
+	result, err := trakt.AddToWatchlist(accessToken, toAdd)
+	if err != nil {
+		return err
+	}
+	utils.PrintSyncResult(result)
+	return nil
+}
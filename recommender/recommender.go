@@ -0,0 +1,203 @@
+package recommender
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// 各类特征在打分时的权重
+var featureWeights = map[string]float64{
+	"genre":    1.0,
+	"network":  0.8,
+	"country":  0.5,
+	"language": 0.5,
+}
+
+// featureNames 特征类别的中文名称（用于推荐理由）
+var featureNames = map[string]string{
+	"genre":    "类型",
+	"network":  "电视网",
+	"country":  "国家",
+	"language": "语言",
+}
+
+// Profile 用户口味画像（特征 → 偏好权重，正值表示喜欢，负值表示不喜欢）
+type Profile struct {
+	Features map[string]float64
+	Titles   int             // 参与构建画像的条目数
+	Watched  map[string]bool // 已观看/已评分条目（movie:ID、show:ID），推荐时排除
+}
+
+// Reason 推荐理由（单个特征的贡献）
+type Reason struct {
+	Feature string  // 特征（如 genre:drama）
+	Score   float64 // 对总分的贡献
+}
+
+// Recommendation 打分后的候选条目
+type Recommendation struct {
+	Item    trakt.DiscoverItem
+	Score   float64
+	Reasons []Reason // 按贡献从大到小排列
+}
+
+// BuildProfile 根据已观看的电影、剧集与评分构建口味画像
+// 已评分条目按评分加权（6分以下为负向偏好），未评分条目按观看次数加权
+func BuildProfile(movies []trakt.TraktWatchedMovie, shows []trakt.TraktWatchedShow, ratings []trakt.TraktRating) *Profile {
+	p := &Profile{Features: make(map[string]float64), Watched: make(map[string]bool)}
+
+	rated := make(map[string]int)
+	for _, r := range ratings {
+		switch {
+		case r.Movie != nil && r.Type == "movie":
+			rated[itemKey("movie", r.Movie.IDs.Trakt)] = r.Rating
+		case r.Show != nil && r.Type == "show":
+			rated[itemKey("show", r.Show.IDs.Trakt)] = r.Rating
+		}
+	}
+
+	add := func(key string, features []string, plays int) {
+		p.Watched[key] = true
+		p.Titles++
+		weight := math.Log2(float64(plays) + 1)
+		if rating, ok := rated[key]; ok {
+			weight = (float64(rating) - 5.5) / 2
+		}
+		for _, f := range features {
+			p.Features[f] += weight
+		}
+	}
+
+	for _, m := range movies {
+		add(itemKey("movie", m.Movie.IDs.Trakt), MovieFeatures(&m.Movie), m.Plays)
+	}
+	for _, s := range shows {
+		add(itemKey("show", s.Show.IDs.Trakt), ShowFeatures(&s.Show), s.WatchedEpisodes())
+	}
+
+	// 只评分未观看的条目也计入画像
+	for _, r := range ratings {
+		if r.Movie != nil && r.Type == "movie" && !p.Watched[itemKey("movie", r.Movie.IDs.Trakt)] {
+			add(itemKey("movie", r.Movie.IDs.Trakt), MovieFeatures(r.Movie), 0)
+		}
+		if r.Show != nil && r.Type == "show" && !p.Watched[itemKey("show", r.Show.IDs.Trakt)] {
+			add(itemKey("show", r.Show.IDs.Trakt), ShowFeatures(r.Show), 0)
+		}
+	}
+
+	// 按条目数归一化，使画像不受观看总量影响
+	if p.Titles > 0 {
+		for f := range p.Features {
+			p.Features[f] /= float64(p.Titles)
+		}
+	}
+	return p
+}
+
+// Score 为候选条目打分并按分数从高到低排序（已观看条目与重复条目会被排除）
+func (p *Profile) Score(candidates []trakt.DiscoverItem) []Recommendation {
+	seen := make(map[string]bool)
+	var results []Recommendation
+	for _, item := range candidates {
+		var key string
+		var features []string
+		var rating float64
+		switch {
+		case item.Movie != nil:
+			key, features, rating = itemKey("movie", item.Movie.IDs.Trakt), MovieFeatures(item.Movie), item.Movie.Rating
+		case item.Show != nil:
+			key, features, rating = itemKey("show", item.Show.IDs.Trakt), ShowFeatures(item.Show), item.Show.Rating
+		default:
+			continue
+		}
+		if p.Watched[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		rec := Recommendation{Item: item}
+		for _, f := range features {
+			contribution := p.Features[f] * featureWeights[featureCategory(f)]
+			if contribution == 0 {
+				continue
+			}
+			rec.Score += contribution
+			rec.Reasons = append(rec.Reasons, Reason{Feature: f, Score: contribution})
+		}
+		// 社区评分作为轻微加成（满分10分时 +0.1）
+		rec.Score += rating / 100
+		sort.Slice(rec.Reasons, func(i, j int) bool {
+			return rec.Reasons[i].Score > rec.Reasons[j].Score
+		})
+		results = append(results, rec)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// TopFeatures 画像中偏好最强的特征（limit<=0 时返回全部）
+func (p *Profile) TopFeatures(limit int) []Reason {
+	var list []Reason
+	for f, score := range p.Features {
+		list = append(list, Reason{Feature: f, Score: score * featureWeights[featureCategory(f)]})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Score != list[j].Score {
+			return list[i].Score > list[j].Score
+		}
+		return list[i].Feature < list[j].Feature
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// Describe 推荐理由的中文描述（如「类型 drama」）
+func (r Reason) Describe() string {
+	category, value, _ := strings.Cut(r.Feature, ":")
+	return featureNames[category] + " " + value
+}
+
+// MovieFeatures 提取电影的内容特征
+func MovieFeatures(m *trakt.TraktMovie) []string {
+	return buildFeatures(m.Genres, "", m.Country, m.Language)
+}
+
+// ShowFeatures 提取剧集的内容特征
+func ShowFeatures(s *trakt.TraktShow) []string {
+	return buildFeatures(s.Genres, s.Network, s.Country, s.Language)
+}
+
+// buildFeatures 组装特征列表（空值忽略，统一小写）
+func buildFeatures(genres []string, network, country, language string) []string {
+	var features []string
+	for _, g := range genres {
+		features = append(features, "genre:"+strings.ToLower(g))
+	}
+	for category, value := range map[string]string{"network": network, "country": country, "language": language} {
+		if value != "" {
+			features = append(features, category+":"+strings.ToLower(value))
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// featureCategory 特征的类别（genre、network、country、language）
+func featureCategory(feature string) string {
+	category, _, _ := strings.Cut(feature, ":")
+	return category
+}
+
+// itemKey 条目的唯一键
+func itemKey(itemType string, traktID int) string {
+	return itemType + ":" + strconv.Itoa(traktID)
+}
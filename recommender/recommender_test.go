@@ -0,0 +1,140 @@
+package recommender
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+
+	"traktshow/trakt"
+)
+
+// 缓存数据的样例（与 /sync/watched、/sync/ratings 的响应格式相同）
+const (
+	watchedMoviesJSON = `[
+		{"plays": 1, "movie": {"title": "Drama Movie", "year": 2019, "ids": {"trakt": 1}, "genres": ["drama"], "country": "us", "language": "en"}},
+		{"plays": 3, "movie": {"title": "Horror Movie", "year": 2020, "ids": {"trakt": 2}, "genres": ["horror"], "country": "us", "language": "en"}}
+	]`
+	watchedShowsJSON = `[
+		{"plays": 2, "show": {"title": "Crime Show", "year": 2018, "ids": {"trakt": 10}, "genres": ["drama", "crime"], "network": "HBO", "country": "us", "language": "en"},
+		 "seasons": [{"number": 0, "episodes": [{"number": 1, "plays": 1}]}, {"number": 1, "episodes": [{"number": 1, "plays": 1}, {"number": 2, "plays": 1}]}]}
+	]`
+	ratingsJSON = `[
+		{"rating": 9, "type": "movie", "movie": {"title": "Drama Movie", "year": 2019, "ids": {"trakt": 1}, "genres": ["drama"], "country": "us", "language": "en"}},
+		{"rating": 3, "type": "movie", "movie": {"title": "Horror Movie", "year": 2020, "ids": {"trakt": 2}, "genres": ["horror"], "country": "us", "language": "en"}},
+		{"rating": 8, "type": "movie", "movie": {"title": "Comedy Movie", "year": 2021, "ids": {"trakt": 3}, "genres": ["comedy"]}},
+		{"rating": 7, "type": "episode", "episode": {"season": 1, "number": 1, "ids": {"trakt": 100}}}
+	]`
+)
+
+func testProfile(t *testing.T) *Profile {
+	t.Helper()
+	var movies []trakt.TraktWatchedMovie
+	var shows []trakt.TraktWatchedShow
+	var ratings []trakt.TraktRating
+	for data, v := range map[string]any{watchedMoviesJSON: &movies, watchedShowsJSON: &shows, ratingsJSON: &ratings} {
+		if err := json.Unmarshal([]byte(data), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return BuildProfile(movies, shows, ratings)
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestBuildProfile(t *testing.T) {
+	p := testProfile(t)
+
+	if p.Titles != 4 {
+		t.Errorf("Titles = %d, want 4", p.Titles)
+	}
+	for _, key := range []string{"movie:1", "movie:2", "movie:3", "show:10"} {
+		if !p.Watched[key] {
+			t.Errorf("Watched[%q] = false", key)
+		}
+	}
+
+	// 已评分：(评分 - 5.5) / 2；未评分：log2(观看集数 + 1)（不含特别篇）；按条目数归一化
+	showWeight := math.Log2(3)
+	tests := map[string]float64{
+		"genre:drama":   (1.75 + showWeight) / 4,
+		"genre:horror":  -1.25 / 4,
+		"genre:crime":   showWeight / 4,
+		"genre:comedy":  1.25 / 4,
+		"network:hbo":   showWeight / 4,
+		"country:us":    (1.75 - 1.25 + showWeight) / 4,
+		"language:en":   (1.75 - 1.25 + showWeight) / 4,
+		"genre:romance": 0,
+	}
+	for feature, want := range tests {
+		if got := p.Features[feature]; !approx(got, want) {
+			t.Errorf("Features[%q] = %v, want %v", feature, got, want)
+		}
+	}
+}
+
+func TestBuildProfileEmpty(t *testing.T) {
+	p := BuildProfile(nil, nil, nil)
+	if p.Titles != 0 || len(p.Features) != 0 {
+		t.Errorf("empty profile = %+v", p)
+	}
+}
+
+func TestScore(t *testing.T) {
+	p := testProfile(t)
+	candidates := []trakt.DiscoverItem{
+		{Movie: &trakt.TraktMovie{Title: "Another Drama", IDs: trakt.TraktIDs{Trakt: 20}, Genres: []string{"drama"}, Rating: 8}},
+		{Movie: &trakt.TraktMovie{Title: "Another Horror", IDs: trakt.TraktIDs{Trakt: 21}, Genres: []string{"horror"}, Rating: 9}},
+		{Movie: &trakt.TraktMovie{Title: "Drama Movie", IDs: trakt.TraktIDs{Trakt: 1}, Genres: []string{"drama"}}},
+		{Movie: &trakt.TraktMovie{Title: "Another Drama", IDs: trakt.TraktIDs{Trakt: 20}, Genres: []string{"drama"}, Rating: 8}},
+		{Show: &trakt.TraktShow{Title: "Another Crime Show", IDs: trakt.TraktIDs{Trakt: 30}, Genres: []string{"drama", "crime"}, Network: "HBO"}},
+		{Watchers: 10},
+	}
+	results := p.Score(candidates)
+
+	var titles []string
+	for _, r := range results {
+		if r.Item.Movie != nil {
+			titles = append(titles, r.Item.Movie.Title)
+		} else {
+			titles = append(titles, r.Item.Show.Title)
+		}
+	}
+	// 已观看、重复与无效的条目被排除，按分数从高到低排列
+	want := []string{"Another Crime Show", "Another Drama", "Another Horror"}
+	if !slices.Equal(titles, want) {
+		t.Fatalf("results = %v, want %v", titles, want)
+	}
+
+	drama := p.Features["genre:drama"]
+	if got, want := results[1].Score, drama+0.08; !approx(got, want) {
+		t.Errorf("Another Drama score = %v, want %v", got, want)
+	}
+	if results[2].Score >= 0 {
+		t.Errorf("Another Horror score = %v, want negative", results[2].Score)
+	}
+
+	reasons := results[0].Reasons
+	if len(reasons) != 3 || reasons[0].Feature != "genre:drama" || reasons[0].Describe() != "类型 drama" {
+		t.Errorf("reasons = %+v", reasons)
+	}
+	for i := 1; i < len(reasons); i++ {
+		if reasons[i].Score > reasons[i-1].Score {
+			t.Errorf("reasons not sorted: %+v", reasons)
+		}
+	}
+}
+
+func TestTopFeatures(t *testing.T) {
+	p := testProfile(t)
+	top := p.TopFeatures(2)
+	if len(top) != 2 || top[0].Feature != "genre:drama" || top[1].Feature != "genre:crime" {
+		t.Errorf("TopFeatures(2) = %+v", top)
+	}
+	all := p.TopFeatures(0)
+	if len(all) != len(p.Features) || all[len(all)-1].Feature != "genre:horror" {
+		t.Errorf("TopFeatures(0) = %+v", all)
+	}
+}
@@ -0,0 +1,277 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+)
+
+// ErrNoMatch 文件名无法匹配到 Trakt 条目（确定的结果，区别于请求失败）
+var ErrNoMatch = errors.New("无法匹配")
+
+// Match 文件名与 Trakt 条目的匹配结果
+type Match struct {
+	Info       Info
+	Movie      *trakt.TraktMovie
+	Show       *trakt.TraktShow
+	Episode    *trakt.TraktEpisode // 未能定位到具体单集时为 nil
+	Confidence float64             // 匹配置信度（0-1）
+}
+
+// Matcher 将解析结果匹配为 Trakt 条目（观看记录中出现过的剧集与单集优先匹配，且无需额外请求）
+type Matcher struct {
+	token   *oauth2.Token
+	history []trakt.TraktWatchHistoryItem
+	seasons map[int][]trakt.TraktSeason // 剧集 Trakt ID → 分季信息
+}
+
+// NewMatcher 创建匹配器（history 可为空）
+func NewMatcher(token *oauth2.Token, history []trakt.TraktWatchHistoryItem) *Matcher {
+	return &Matcher{token: token, history: history, seasons: make(map[int][]trakt.TraktSeason)}
+}
+
+// candidate 待打分的候选条目
+type candidate struct {
+	title string
+	year  int
+	movie *trakt.TraktMovie
+	show  *trakt.TraktShow
+	known bool // 出现在观看记录中
+}
+
+// Match 匹配文件名解析结果（未找到候选时返回包装 ErrNoMatch 的错误）
+func (m *Matcher) Match(info Info) (*Match, error) {
+	if info.Title == "" {
+		return nil, fmt.Errorf("%w：文件名中没有标题", ErrNoMatch)
+	}
+	candidates, err := m.candidates(info)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *Match
+	for i, c := range candidates {
+		confidence := score(info, c, i)
+		if best == nil || confidence > best.Confidence {
+			best = &Match{Info: info, Movie: c.movie, Show: c.show, Confidence: confidence}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w：未找到「%s」", ErrNoMatch, info.Title)
+	}
+
+	if info.Kind == "episode" {
+		episode, err := m.findEpisode(best.Show, info)
+		if err != nil {
+			return nil, err
+		}
+		best.Episode = episode
+		// 剧集匹配但单集无法定位时降低置信度
+		if episode == nil {
+			best.Confidence /= 2
+		}
+	}
+	return best, nil
+}
+
+// score 候选条目的置信度（标题相似度，按年份与是否出现在观看记录中加减分，排名靠后的搜索结果略微降低）
+func score(info Info, c candidate, rank int) float64 {
+	confidence := titleSimilarity(info.Title, c.title)
+	switch {
+	case info.Year == 0 || c.year == 0:
+	case info.Year == c.year:
+		confidence += 0.15
+	case info.Year-c.year == 1 || c.year-info.Year == 1:
+		confidence += 0.05
+	default:
+		confidence -= 0.3
+	}
+	if c.known {
+		confidence += 0.1
+	}
+	confidence -= 0.02 * float64(rank)
+	return min(max(confidence, 0), 1)
+}
+
+// candidates 收集候选条目（观看记录中的同名条目在前，随后为搜索结果）
+func (m *Matcher) candidates(info Info) ([]candidate, error) {
+	var list []candidate
+	seen := make(map[int]bool)
+	target := normalizeTitle(info.Title)
+	for i := range m.history {
+		item := &m.history[i]
+		switch {
+		case info.Kind == "movie" && item.Movie != nil:
+			if !seen[item.Movie.IDs.Trakt] && normalizeTitle(item.Movie.Title) == target {
+				seen[item.Movie.IDs.Trakt] = true
+				list = append(list, candidate{title: item.Movie.Title, year: item.Movie.Year, movie: item.Movie, known: true})
+			}
+		case info.Kind == "episode" && item.Show != nil:
+			if !seen[item.Show.IDs.Trakt] && normalizeTitle(item.Show.Title) == target {
+				seen[item.Show.IDs.Trakt] = true
+				show := item.ShowInfo()
+				list = append(list, candidate{title: show.Title, year: show.Year, show: show, known: true})
+			}
+		}
+	}
+
+	searchType := "movie"
+	if info.Kind == "episode" {
+		searchType = "show"
+	}
+	var opts trakt.SearchOptions
+	opts.Limit = 10
+	// 剧集文件名中的年份通常是首播年份，只用于打分，不作为搜索条件
+	if info.Year > 0 && info.Kind == "movie" {
+		opts.Years = fmt.Sprintf("%d-%d", info.Year-1, info.Year+1)
+	}
+	results, err := trakt.Search(m.token, []string{searchType}, info.Title, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		r := &results[i]
+		if seen[r.IDs().Trakt] {
+			continue
+		}
+		seen[r.IDs().Trakt] = true
+		list = append(list, candidate{title: r.Title(), year: r.Year(), movie: r.Movie, show: r.Show})
+	}
+	return list, nil
+}
+
+// findEpisode 在剧集中定位单集（按季号/集号、绝对集数或播出日期）
+func (m *Matcher) findEpisode(show *trakt.TraktShow, info Info) (*trakt.TraktEpisode, error) {
+	if show == nil {
+		return nil, nil
+	}
+
+	// 观看记录中已有的单集
+	for i := range m.history {
+		item := &m.history[i]
+		if item.Show == nil || item.Episode == nil || item.Show.IDs.Trakt != show.IDs.Trakt {
+			continue
+		}
+		e := item.Episode
+		if (info.Season > 0 || info.Episode > 0) && e.Season == info.Season && e.Number == info.Episode ||
+			info.Absolute > 0 && e.NumberAbs == info.Absolute {
+			return e, nil
+		}
+	}
+
+	showID := strconv.Itoa(show.IDs.Trakt)
+	if info.Absolute == 0 && info.AirDate.IsZero() {
+		return trakt.GetEpisode(m.token, showID, info.Season, info.Episode)
+	}
+
+	seasons, ok := m.seasons[show.IDs.Trakt]
+	if !ok {
+		var err error
+		if seasons, err = trakt.GetSeasons(m.token, showID); err != nil {
+			return nil, err
+		}
+		m.seasons[show.IDs.Trakt] = seasons
+	}
+
+	if !info.AirDate.IsZero() {
+		// 播出时间为 UTC，与本地日期可能相差一天
+		for _, s := range seasons {
+			for i := range s.Episodes {
+				e := &s.Episodes[i]
+				if e.FirstAired != nil && e.FirstAired.Sub(info.AirDate).Abs().Hours() < 36 {
+					return e, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	// 优先使用 Trakt 提供的绝对集数，缺失时按正片各季顺序累计
+	count := 0
+	for _, s := range seasons {
+		if s.Number == 0 {
+			continue
+		}
+		for i := range s.Episodes {
+			e := &s.Episodes[i]
+			count++
+			if e.NumberAbs == info.Absolute || e.NumberAbs == 0 && count == info.Absolute {
+				return e, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Request 转换为 scrobble 请求（未定位到单集时按季号/集号或绝对集数提交）
+func (m *Match) Request() trakt.ScrobbleRequest {
+	var req trakt.ScrobbleRequest
+	switch {
+	case m.Movie != nil:
+		req.Movie = &trakt.SyncItem{IDs: m.Movie.IDs}
+	case m.Episode != nil:
+		ids := m.Episode.IDs
+		req.Episode = &trakt.ScrobbleEpisode{IDs: &ids}
+	case m.Show != nil:
+		req.Show = &trakt.SyncItem{IDs: m.Show.IDs}
+		req.Episode = &trakt.ScrobbleEpisode{Season: m.Info.Season, Number: m.Info.Episode, NumberAbs: m.Info.Absolute}
+	}
+	return req
+}
+
+// Describe 匹配结果的描述（如「Show Name S02E05」）
+func (m *Match) Describe() string {
+	switch {
+	case m.Movie != nil:
+		return fmt.Sprintf("%s (%d)", m.Movie.Title, m.Movie.Year)
+	case m.Show != nil && m.Episode != nil:
+		return fmt.Sprintf("%s S%02dE%02d", m.Show.Title, m.Episode.Season, m.Episode.Number)
+	case m.Show != nil:
+		return m.Show.Title + "（未定位到单集）"
+	}
+	return m.Info.Title
+}
+
+// titleSimilarity 标题相似度（0-1，规范化后完全相同为1，否则按词的重合程度计算）
+func titleSimilarity(a, b string) float64 {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if a == b {
+		return 1
+	}
+	wordsA, wordsB := strings.Fields(a), strings.Fields(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	set := make(map[string]bool)
+	for _, w := range wordsA {
+		set[w] = true
+	}
+	common := 0
+	for _, w := range wordsB {
+		if set[w] {
+			common++
+		}
+	}
+	return 0.9 * float64(common) / float64(max(len(wordsA), len(wordsB)))
+}
+
+// normalizeTitle 规范化标题（小写、去除标点与开头的冠词，& 视为 and）
+func normalizeTitle(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, "&", " and "))
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		if r == '\'' || r == '’' {
+			return -1
+		}
+		return ' '
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.TrimPrefix(s, "the ")
+}
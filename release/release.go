@@ -0,0 +1,152 @@
+package release
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Info 从文件名解析出的条目信息
+type Info struct {
+	Kind       string // movie、episode
+	Title      string // 电影名称或剧集名称
+	Year       int
+	Season     int
+	Episode    int
+	Absolute   int       // 绝对集数（动画常见，Season/Episode 为0）
+	AirDate    time.Time // 按播出日期命名的单集（如日播节目）
+	Resolution string    // 2160p、1080p、720p 等
+	Group      string    // 发布组
+}
+
+// VideoExtensions 视为视频文件的扩展名（小写）
+var VideoExtensions = map[string]bool{
+	".mkv": true, ".mp4": true, ".m4v": true, ".avi": true, ".mov": true,
+	".ts": true, ".m2ts": true, ".wmv": true, ".webm": true, ".mpg": true,
+}
+
+var (
+	// Show.Name.S02E05...、Show Name - s2e5、S02E05（剧集目录中只有季号/集号的文件名）
+	seasonEpisodePattern = regexp.MustCompile(`(?i)^(?:(.+?)[\s._-]+)?s(\d{1,2})[\s._-]?e(\d{1,3})\b`)
+	// Show.Name.2x05...
+	crossEpisodePattern = regexp.MustCompile(`(?i)^(.+?)[\s._-]+(\d{1,2})x(\d{2,3})\b`)
+	// Show.Name.2024.03.15...
+	airDatePattern = regexp.MustCompile(`^(.+?)[\s._-]+((?:19|20)\d{2})[\s._-](\d{2})[\s._-](\d{2})\b`)
+	// [Group] Show Name - 1071 (1080p)、Show.Name.E1071、Show Name EP12
+	absoluteDashPattern   = regexp.MustCompile(`^(.+?)\s+-\s+(\d{1,4})(?:v\d)?(?:\s|$)`)
+	absolutePrefixPattern = regexp.MustCompile(`(?i)^(.+?)[\s._-]+(?:e|ep)(\d{1,4})(?:v\d)?\b`)
+	// Movie (2019)、Movie.2019.1080p...（贪婪匹配取最后一个年份，如 Blade.Runner.2049.2017）
+	movieYearPattern = regexp.MustCompile(`^(.+)[\s._(\[-]+((?:19|20)\d{2})(?:[\s._)\]-]|$)`)
+	// 标题中的年份（如 Show.Name.2019.S01E01）
+	trailingYearPattern = regexp.MustCompile(`[\s(\[]+((?:19|20)\d{2})[)\]]?$`)
+
+	resolutionPattern = regexp.MustCompile(`(?i)\b(2160p|1080p|720p|576p|480p|4k|uhd)\b`)
+	// 画质、来源、编码等标签（用于截断没有年份的标题）
+	qualityTagPattern    = regexp.MustCompile(`(?i)[\s._-]+(?:2160p|1080p|720p|576p|480p|4k|uhd|web(?:-?dl|rip)?|bluray|bdrip|brrip|hdtv|dvdrip|remux|x26[45]|h\.?26[45]|hevc|proper|repack)\b`)
+	leadingGroupPattern  = regexp.MustCompile(`^\[([^\]]+)\]\s*`)
+	trailingGroupPattern = regexp.MustCompile(`-([A-Za-z0-9]+)$`)
+	bracketPattern       = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)`)
+	yearOnlyPattern      = regexp.MustCompile(`^\((?:19|20)\d{2}\)$`)
+)
+
+// Parse 解析发布文件名（可带目录与扩展名，只去除 VideoExtensions 中的扩展名）
+func Parse(name string) Info {
+	name = filepath.Base(name)
+	if ext := filepath.Ext(name); VideoExtensions[strings.ToLower(ext)] {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	var info Info
+	if m := resolutionPattern.FindStringSubmatch(name); m != nil {
+		info.Resolution = normalizeResolution(m[1])
+	}
+
+	// 动画发布常见的 [组名] 前缀，其余方括号/圆括号内容（画质、校验值等）一并去除，仅保留年份
+	if m := leadingGroupPattern.FindStringSubmatch(name); m != nil {
+		info.Group = m[1]
+		name = name[len(m[0]):]
+	} else if tags := qualityTagPattern.FindAllStringIndex(name, -1); tags != nil && tags[len(tags)-1][1] < len(name) {
+		// 以标签结尾时（如 WEB-DL）没有发布组
+		if m := trailingGroupPattern.FindStringSubmatch(name); m != nil {
+			info.Group = m[1]
+		}
+	}
+	name = strings.TrimSpace(bracketPattern.ReplaceAllStringFunc(name, func(s string) string {
+		if yearOnlyPattern.MatchString(s) {
+			return s
+		}
+		return " "
+	}))
+
+	for _, pattern := range []*regexp.Regexp{seasonEpisodePattern, crossEpisodePattern} {
+		if m := pattern.FindStringSubmatch(name); m != nil {
+			info.Kind = "episode"
+			info.Title, info.Year = splitYear(cleanTitle(m[1]))
+			info.Season, _ = strconv.Atoi(m[2])
+			info.Episode, _ = strconv.Atoi(m[3])
+			return info
+		}
+	}
+
+	if m := airDatePattern.FindStringSubmatch(name); m != nil {
+		if date, err := time.Parse("2006-01-02", m[2]+"-"+m[3]+"-"+m[4]); err == nil {
+			info.Kind = "episode"
+			info.Title, info.Year = splitYear(cleanTitle(m[1]))
+			info.AirDate = date
+			return info
+		}
+	}
+
+	for _, pattern := range []*regexp.Regexp{absoluteDashPattern, absolutePrefixPattern} {
+		if m := pattern.FindStringSubmatch(name); m != nil {
+			number, _ := strconv.Atoi(m[2])
+			// 形如「Movie - 2019」的四位数更可能是年份
+			if number >= 1900 && number < 2100 {
+				continue
+			}
+			info.Kind = "episode"
+			info.Title, info.Year = splitYear(cleanTitle(m[1]))
+			info.Absolute = number
+			return info
+		}
+	}
+
+	// 年份只在画质、来源等标签之前查找
+	info.Kind = "movie"
+	if loc := qualityTagPattern.FindStringIndex(name); loc != nil {
+		name = name[:loc[0]]
+	}
+	if m := movieYearPattern.FindStringSubmatch(name); m != nil {
+		info.Title = cleanTitle(m[1])
+		info.Year, _ = strconv.Atoi(m[2])
+		return info
+	}
+	info.Title = cleanTitle(name)
+	return info
+}
+
+// cleanTitle 将分隔符替换为空格并去除首尾多余字符
+func cleanTitle(s string) string {
+	s = strings.NewReplacer(".", " ", "_", " ").Replace(s)
+	return strings.Trim(strings.Join(strings.Fields(s), " "), " -")
+}
+
+// splitYear 拆分标题末尾的年份
+func splitYear(title string) (string, int) {
+	if m := trailingYearPattern.FindStringSubmatchIndex(title); m != nil {
+		year, _ := strconv.Atoi(title[m[2]:m[3]])
+		return strings.TrimSpace(title[:m[0]]), year
+	}
+	return title, 0
+}
+
+// normalizeResolution 统一分辨率写法（4k、uhd 视为 2160p）
+func normalizeResolution(s string) string {
+	s = strings.ToLower(s)
+	if s == "4k" || s == "uhd" {
+		return "2160p"
+	}
+	return s
+}
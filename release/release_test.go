@@ -0,0 +1,97 @@
+package release
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		want Info
+	}{
+		{"Show.Name.S02E05.1080p.WEB.x264-GRP.mkv",
+			Info{Kind: "episode", Title: "Show Name", Season: 2, Episode: 5, Resolution: "1080p", Group: "GRP"}},
+		{"/media/tv/Show Name - s2e5.mp4",
+			Info{Kind: "episode", Title: "Show Name", Season: 2, Episode: 5}},
+		{"Show.Name.2019.S01E01.720p.HDTV.x264-GRP.mkv",
+			Info{Kind: "episode", Title: "Show Name", Year: 2019, Season: 1, Episode: 1, Resolution: "720p", Group: "GRP"}},
+		{"S01E02.mkv",
+			Info{Kind: "episode", Season: 1, Episode: 2}},
+		{"s00e01 - Special.mkv",
+			Info{Kind: "episode", Season: 0, Episode: 1}},
+		{"Show.Name.2x05.mkv",
+			Info{Kind: "episode", Title: "Show Name", Season: 2, Episode: 5}},
+		{"Show.Name.S01E01",
+			Info{Kind: "episode", Title: "Show Name", Season: 1, Episode: 1}},
+		{"Daily.Show.2024.03.15.1080p.WEB.h264-GRP.mkv",
+			Info{Kind: "episode", Title: "Daily Show", AirDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), Resolution: "1080p", Group: "GRP"}},
+		{"[SubGroup] One Piece - 1071 (1080p) [ABCD1234].mkv",
+			Info{Kind: "episode", Title: "One Piece", Absolute: 1071, Resolution: "1080p", Group: "SubGroup"}},
+		{"One.Piece.E1071.mkv",
+			Info{Kind: "episode", Title: "One Piece", Absolute: 1071}},
+		{"Movie (2019).mkv",
+			Info{Kind: "movie", Title: "Movie", Year: 2019}},
+		{"Movie.2019",
+			Info{Kind: "movie", Title: "Movie", Year: 2019}},
+		{"Movie.Name.2019.2160p.UHD.BluRay.REMUX-GRP.mkv",
+			Info{Kind: "movie", Title: "Movie Name", Year: 2019, Resolution: "2160p", Group: "GRP"}},
+		{"Blade.Runner.2049.2017.1080p.BluRay.x264-GRP.mkv",
+			Info{Kind: "movie", Title: "Blade Runner 2049", Year: 2017, Resolution: "1080p", Group: "GRP"}},
+		{"1917 (2019).mkv",
+			Info{Kind: "movie", Title: "1917", Year: 2019}},
+		{"Movie.Without.Year.1080p.WEB-DL.mkv",
+			Info{Kind: "movie", Title: "Movie Without Year", Resolution: "1080p"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.name); got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name string
+		info Info
+		c    candidate
+		rank int
+		want float64
+	}{
+		{"exact title and year", Info{Title: "Movie", Year: 2019}, candidate{title: "Movie", year: 2019}, 0, 1},
+		{"no year", Info{Title: "Show Name"}, candidate{title: "Show Name", year: 2010}, 0, 1},
+		{"year off by one", Info{Title: "The Movie", Year: 2019}, candidate{title: "Movie", year: 2020}, 0, 1},
+		{"wrong year", Info{Title: "Movie", Year: 2019}, candidate{title: "Movie", year: 1984}, 0, 0.7},
+		{"lower rank", Info{Title: "Movie"}, candidate{title: "Movie"}, 5, 0.9},
+		{"partial title", Info{Title: "Show Name"}, candidate{title: "Show Name Returns"}, 0, 0.6},
+		{"known partial title", Info{Title: "Show Name"}, candidate{title: "Show Name Returns", known: true}, 0, 0.7},
+		{"unrelated title", Info{Title: "Movie", Year: 2019}, candidate{title: "Other", year: 2000}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := score(tt.info, tt.c, tt.rank); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"The Office", "Office", 1},
+		{"Law & Order", "Law and Order", 1},
+		{"Grey's Anatomy", "Greys Anatomy", 1},
+		{"Show Name", "Show Name Returns", 0.6},
+		{"Show", "", 0},
+	}
+	for _, tt := range tests {
+		if got := titleSimilarity(tt.a, tt.b); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("titleSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
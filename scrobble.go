@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runScrobble 上报播放状态（start/pause/stop）
+func runScrobble(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法：scrobble start|pause|stop -type movie|episode -progress <百分比> <条目>")
+	}
+	action := args[0]
+	scrobble := trakt.ScrobbleStart
+	switch action {
+	case "start":
+	case "pause":
+		scrobble = trakt.ScrobblePause
+	case "stop":
+		scrobble = trakt.ScrobbleStop
+	default:
+		return fmt.Errorf("未知的子命令：%s（可用：start、pause、stop）", action)
+	}
+
+	fs := flag.NewFlagSet("scrobble "+action, flag.ExitOnError)
+	target := addItemFlags(fs)
+	progress := fs.Float64("progress", 0, "播放进度百分比（0-100）")
+	fs.Parse(args[1:])
+
+	if *progress < 0 || *progress > 100 {
+		return fmt.Errorf("播放进度必须在 0-100 之间")
+	}
+	if len(fs.Args()) != 1 {
+		return fmt.Errorf("请指定一个条目")
+	}
+	req, err := newScrobbleRequest(*target.kind, fs.Arg(0), *target.season, *target.episode)
+	if err != nil {
+		return err
+	}
+	req.Progress = *progress
+
+	result, err := scrobble(accessToken, req)
+	if err != nil {
+		return err
+	}
+	utils.PrintScrobbleResult(result)
+	return nil
+}
+
+// newScrobbleRequest 将命令行中的条目引用解析为 scrobble 请求（仅支持电影和单集）
+func newScrobbleRequest(kind, ref string, season, episode int) (trakt.ScrobbleRequest, error) {
+	var req trakt.ScrobbleRequest
+	if kind != "movie" && kind != "episode" {
+		return req, fmt.Errorf("仅支持电影（movie）和单集（episode）")
+	}
+	items, err := resolveItem(kind, ref, season, episode)
+	if err != nil {
+		return req, err
+	}
+
+	if kind == "movie" {
+		req.Movie = &items.Movies[0]
+		return req, nil
+	}
+	req.Show = &trakt.SyncItem{IDs: items.Shows[0].IDs}
+	req.Episode = &trakt.ScrobbleEpisode{Season: season, Number: episode}
+	return req, nil
+}
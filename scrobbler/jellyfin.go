@@ -0,0 +1,272 @@
+package scrobbler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// Playback Jellyfin/Emby 通知中与播放相关的信息（两种格式统一后的结果）
+type Playback struct {
+	Event    string // start、progress、pause、stop
+	Kind     string // movie、episode
+	Title    string
+	Series   string // 单集所属剧集
+	Year     int
+	Season   int
+	Episode  int
+	IDs      trakt.TraktIDs // 条目自身的外部ID（单集为单集ID）
+	Position int64          // 已播放时长（ticks，1 tick = 100 纳秒）
+	Runtime  int64          // 总时长（ticks）
+	Paused   bool
+	User     string
+	Session  string // 会话键（设备 + 条目）
+}
+
+// jellyfinPayload Jellyfin Webhook 插件默认模板的通知
+type jellyfinPayload struct {
+	NotificationType      string `json:"NotificationType"` // PlaybackStart、PlaybackProgress、PlaybackStop
+	NotificationUsername  string `json:"NotificationUsername"`
+	DeviceID              string `json:"DeviceId"`
+	ItemID                string `json:"ItemId"`
+	ItemType              string `json:"ItemType"` // Movie、Episode
+	Name                  string `json:"Name"`
+	SeriesName            string `json:"SeriesName"`
+	Year                  int    `json:"Year"`
+	SeasonNumber          int    `json:"SeasonNumber"`
+	EpisodeNumber         int    `json:"EpisodeNumber"`
+	ProviderIMDB          string `json:"Provider_imdb"`
+	ProviderTMDb          string `json:"Provider_tmdb"`
+	ProviderTVDB          string `json:"Provider_tvdb"`
+	PlaybackPositionTicks int64  `json:"PlaybackPositionTicks"`
+	RunTimeTicks          int64  `json:"RunTimeTicks"`
+	IsPaused              bool   `json:"IsPaused"`
+	PlayedToCompletion    bool   `json:"PlayedToCompletion"`
+}
+
+// embyPayload Emby 通知（Webhooks 插件）
+type embyPayload struct {
+	Event string `json:"Event"` // playback.start、playback.pause、playback.unpause、playback.stop
+	User  struct {
+		Name string `json:"Name"`
+	} `json:"User"`
+	Session struct {
+		ID string `json:"Id"`
+	} `json:"Session"`
+	Item struct {
+		ID                string            `json:"Id"`
+		Type              string            `json:"Type"` // Movie、Episode
+		Name              string            `json:"Name"`
+		SeriesName        string            `json:"SeriesName"`
+		ProductionYear    int               `json:"ProductionYear"`
+		ParentIndexNumber int               `json:"ParentIndexNumber"` // 季号
+		IndexNumber       int               `json:"IndexNumber"`       // 集号
+		ProviderIDs       map[string]string `json:"ProviderIds"`
+		RunTimeTicks      int64             `json:"RunTimeTicks"`
+	} `json:"Item"`
+	PlaybackInfo struct {
+		PositionTicks      int64 `json:"PositionTicks"`
+		PlayedToCompletion bool  `json:"PlayedToCompletion"`
+	} `json:"PlaybackInfo"`
+}
+
+// ParseMediaServerPayload 解析 Jellyfin 或 Emby 的播放通知（按字段自动识别格式）
+// 不是播放事件时返回 nil
+func ParseMediaServerPayload(data []byte) (*Playback, error) {
+	var probe struct {
+		NotificationType string `json:"NotificationType"`
+		Event            string `json:"Event"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("解析通知失败：%v", err)
+	}
+
+	switch {
+	case probe.NotificationType != "":
+		var p jellyfinPayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("解析 Jellyfin 通知失败：%v", err)
+		}
+		return p.playback(), nil
+	case probe.Event != "":
+		var p embyPayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("解析 Emby 通知失败：%v", err)
+		}
+		return p.playback(), nil
+	}
+	return nil, fmt.Errorf("无法识别的通知格式")
+}
+
+// playback 转换为统一的播放信息
+func (p *jellyfinPayload) playback() *Playback {
+	var event string
+	switch p.NotificationType {
+	case "PlaybackStart":
+		event = "start"
+	case "PlaybackProgress":
+		event = "progress"
+	case "PlaybackStop":
+		event = "stop"
+	default:
+		return nil
+	}
+	pb := &Playback{
+		Event:    event,
+		Kind:     strings.ToLower(p.ItemType),
+		Title:    p.Name,
+		Series:   p.SeriesName,
+		Year:     p.Year,
+		Season:   p.SeasonNumber,
+		Episode:  p.EpisodeNumber,
+		Position: p.PlaybackPositionTicks,
+		Runtime:  p.RunTimeTicks,
+		Paused:   p.IsPaused,
+		User:     p.NotificationUsername,
+		Session:  p.DeviceID + "|" + p.ItemID,
+	}
+	pb.IDs.IMDB = p.ProviderIMDB
+	pb.IDs.TMDb, _ = strconv.Atoi(p.ProviderTMDb)
+	pb.IDs.TVDB, _ = strconv.Atoi(p.ProviderTVDB)
+	// 播放完成时停止事件中的位置可能已归零
+	if p.PlayedToCompletion {
+		pb.Position = pb.Runtime
+	}
+	return pb
+}
+
+// playback 转换为统一的播放信息
+func (p *embyPayload) playback() *Playback {
+	var event string
+	switch p.Event {
+	case "playback.start", "playback.unpause":
+		event = "start"
+	case "playback.pause":
+		event = "pause"
+	case "playback.stop":
+		event = "stop"
+	default:
+		return nil
+	}
+	pb := &Playback{
+		Event:    event,
+		Kind:     strings.ToLower(p.Item.Type),
+		Title:    p.Item.Name,
+		Series:   p.Item.SeriesName,
+		Year:     p.Item.ProductionYear,
+		Season:   p.Item.ParentIndexNumber,
+		Episode:  p.Item.IndexNumber,
+		Position: p.PlaybackInfo.PositionTicks,
+		Runtime:  p.Item.RunTimeTicks,
+		User:     p.User.Name,
+		Session:  p.Session.ID + "|" + p.Item.ID,
+	}
+	// ProviderIds 的键名大小写随版本不同
+	for key, value := range p.Item.ProviderIDs {
+		setID(&pb.IDs, strings.ToLower(key), value)
+	}
+	if p.PlaybackInfo.PlayedToCompletion {
+		pb.Position = pb.Runtime
+	}
+	return pb
+}
+
+// Request 转换为 scrobble 请求（无法定位条目时 ok 为 false）
+func (pb *Playback) Request() (req trakt.ScrobbleRequest, ok bool) {
+	req.Progress = progressPercent(float64(pb.Position), float64(pb.Runtime))
+	switch pb.Kind {
+	case "movie":
+		req.Movie = &trakt.SyncItem{IDs: pb.IDs, Title: pb.Title, Year: pb.Year}
+	case "episode":
+		// 优先使用单集自身的ID，否则按剧集名称 + 季号/集号定位
+		if pb.IDs != (trakt.TraktIDs{}) {
+			ids := pb.IDs
+			req.Episode = &trakt.ScrobbleEpisode{IDs: &ids}
+		} else if pb.Series != "" && pb.Episode > 0 {
+			req.Show = &trakt.SyncItem{Title: pb.Series}
+			req.Episode = &trakt.ScrobbleEpisode{Season: pb.Season, Number: pb.Episode}
+		} else {
+			return req, false
+		}
+	default:
+		return req, false
+	}
+	return req, true
+}
+
+// MediaServerHandler 接收 Jellyfin/Emby 播放通知并上报到 Trakt
+type MediaServerHandler struct {
+	Scrobbler   Scrobbler
+	Users       []string // 只处理这些用户的事件（为空时处理全部）
+	MinProgress float64  // 停止播放时标记为已观看所需的最低进度百分比
+	dedup       Dedup
+}
+
+// ServeHTTP 处理通知请求（JSON 请求体；Emby 以表单发送时读取 data 字段）
+func (h *MediaServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var data []byte
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			http.Error(w, "invalid multipart form", http.StatusBadRequest)
+			return
+		}
+		data = []byte(r.FormValue("data"))
+	} else {
+		var err error
+		if data, err = io.ReadAll(io.LimitReader(r.Body, 10<<20)); err != nil {
+			http.Error(w, "read body failed", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pb, err := ParseMediaServerPayload(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if pb == nil || (len(h.Users) > 0 && !slices.Contains(h.Users, pb.User)) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	req, ok := pb.Request()
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// 播放中的进度通知按暂停状态转换为 start/pause
+	action := pb.Event
+	if action == "progress" {
+		action = "start"
+		if pb.Paused {
+			action = "pause"
+		}
+	}
+	if !h.dedup.Allow(pb.Session, action) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	log.Printf("[media-server] %s：%s %s（进度 %.1f%%）", pb.User, pb.Event, pb.Title, req.Progress)
+	if action == "stop" {
+		err = finish(h.Scrobbler, "media-server", req, h.MinProgress)
+	} else {
+		err = send(h.Scrobbler, "media-server", action, req)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package scrobbler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"traktshow/trakt"
+)
+
+func TestParseMediaServerPayload(t *testing.T) {
+	tests := []struct {
+		file string
+		want Playback
+	}{
+		{"jellyfin_stop.json", Playback{
+			Event: "stop", Kind: "episode", Title: "The One Where It Begins", Series: "Show Name", Year: 2019,
+			Season: 2, Episode: 5, IDs: trakt.TraktIDs{IMDB: "tt9876543", TVDB: 7654321},
+			Position: 15120000000, Runtime: 25200000000, User: "alice", Session: "web-device-1|b3c5d7e9f1a3b5c7d9e1f3a5b7c9d1e3",
+		}},
+		{"emby_pause.json", Playback{
+			Event: "pause", Kind: "movie", Title: "Movie Name", Year: 2019, IDs: trakt.TraktIDs{IMDB: "tt0137523", TMDb: 550},
+			Position: 36000000000, Runtime: 72000000000, User: "alice", Session: "session-9|12345",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pb, err := ParseMediaServerPayload(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pb == nil || *pb != tt.want {
+				t.Errorf("ParseMediaServerPayload() = %+v, want %+v", pb, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMediaServerPayloadOther(t *testing.T) {
+	pb, err := ParseMediaServerPayload([]byte(`{"NotificationType":"ItemAdded","Name":"Movie Name"}`))
+	if err != nil || pb != nil {
+		t.Errorf("non-playback event = %+v, %v; want nil, nil", pb, err)
+	}
+	if _, err := ParseMediaServerPayload([]byte(`{"foo":"bar"}`)); err == nil {
+		t.Error("unknown format: want error")
+	}
+	// 播放完成时以总时长作为进度
+	pb, err = ParseMediaServerPayload([]byte(`{"NotificationType":"PlaybackStop","ItemType":"Movie","RunTimeTicks":100,"PlaybackPositionTicks":0,"PlayedToCompletion":true}`))
+	if err != nil || pb.Position != 100 {
+		t.Errorf("played to completion = %+v, %v", pb, err)
+	}
+}
+
+func TestPlaybackRequest(t *testing.T) {
+	pb := Playback{Kind: "episode", Series: "Show Name", Season: 2, Episode: 5, Position: 30, Runtime: 120}
+	req, ok := pb.Request()
+	if !ok || req.Show == nil || req.Show.Title != "Show Name" || req.Episode.Season != 2 || req.Episode.Number != 5 || req.Progress != 25 {
+		t.Errorf("episode without IDs = %+v, %v", req, ok)
+	}
+	if _, ok := (&Playback{Kind: "episode"}).Request(); ok {
+		t.Error("episode without IDs or series: want ok = false")
+	}
+	if _, ok := (&Playback{Kind: "trailer"}).Request(); ok {
+		t.Error("unknown kind: want ok = false")
+	}
+}
+
+func TestMediaServerHandler(t *testing.T) {
+	data, err := os.ReadFile("testdata/jellyfin_stop.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 样例通知的进度为60%
+	tests := []struct {
+		name        string
+		users       []string
+		minProgress float64
+		want        []string
+	}{
+		{"below threshold", nil, 70, []string{"pause"}},
+		{"custom threshold", nil, 50, []string{"stop", "watched"}},
+		{"other user", []string{"bob"}, 50, nil},
+		{"matching user", []string{"alice"}, 80, []string{"pause"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeScrobbler{}
+			h := &MediaServerHandler{Scrobbler: fake, Users: tt.users, MinProgress: tt.minProgress}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/jellyfin", bytes.NewReader(data))
+			r.Header.Set("Content-Type", "application/json")
+			h.ServeHTTP(w, r)
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if got := fake.actions(); !slices.Equal(got, tt.want) {
+				t.Errorf("actions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package scrobbler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	"traktshow/trakt"
+)
+
+// mpv 需要监听的属性（observe_property 的 ID 即为切片下标 + 1）
+var mpvProperties = []string{"path", "duration", "time-pos", "pause"}
+
+// mpvMessage mpv JSON IPC 的事件或命令响应
+type mpvMessage struct {
+	Event  string          `json:"event"`
+	Name   string          `json:"name"`
+	Data   json.RawMessage `json:"data"`
+	Reason string          `json:"reason"` // end-file 的原因（eof、stop、quit 等）
+	Error  string          `json:"error"`
+}
+
+// MPVWatcher 通过 mpv 的 JSON IPC 监听播放状态并上报到 Trakt
+type MPVWatcher struct {
+	Scrobbler Scrobbler
+	// Identify 根据文件路径识别条目（无法识别时返回错误，该文件不上报）
+	Identify func(path string) (trakt.ScrobbleRequest, error)
+
+	path     string
+	req      *trakt.ScrobbleRequest
+	started  bool
+	paused   bool
+	duration float64
+	position float64
+}
+
+// DialMPV 连接 mpv 的 IPC socket（mpv 需以 --input-ipc-server=<socket> 启动）
+func DialMPV(socket string) (net.Conn, error) {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("连接 mpv 失败：%v", err)
+	}
+	return conn, nil
+}
+
+// Watch 在已建立的连接上监听播放状态，直到 mpv 退出或连接断开
+// 连接断开前正在播放的条目会以最后的进度上报 stop
+func (w *MPVWatcher) Watch(conn io.ReadWriter) error {
+	encoder := json.NewEncoder(conn)
+	for i, name := range mpvProperties {
+		if err := encoder.Encode(map[string]any{"command": []any{"observe_property", i + 1, name}}); err != nil {
+			return fmt.Errorf("发送 IPC 命令失败：%v", err)
+		}
+	}
+
+	decoder := json.NewDecoder(conn)
+	for {
+		var msg mpvMessage
+		if err := decoder.Decode(&msg); err != nil {
+			w.stop()
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("读取 IPC 消息失败：%v", err)
+		}
+		switch msg.Event {
+		case "property-change":
+			w.propertyChanged(msg.Name, msg.Data)
+		case "end-file":
+			if msg.Reason == "eof" {
+				w.position = w.duration
+			}
+			w.stop()
+		case "shutdown":
+			w.stop()
+			return nil
+		}
+	}
+}
+
+// propertyChanged 处理属性变化
+func (w *MPVWatcher) propertyChanged(name string, data json.RawMessage) {
+	switch name {
+	case "path":
+		var path string
+		json.Unmarshal(data, &path) // 没有文件时为 null
+		if path == w.path {
+			return
+		}
+		w.stop()
+		w.path, w.req, w.duration, w.position = path, nil, 0, 0
+		if path == "" {
+			return
+		}
+		req, err := w.Identify(path)
+		if err != nil {
+			log.Printf("⚠️  [mpv] 无法识别 %s：%v", path, err)
+			return
+		}
+		w.req = &req
+		w.maybeStart()
+	case "duration":
+		json.Unmarshal(data, &w.duration)
+		w.maybeStart()
+	case "time-pos":
+		json.Unmarshal(data, &w.position)
+	case "pause":
+		var paused bool
+		json.Unmarshal(data, &paused)
+		if paused == w.paused {
+			return
+		}
+		w.paused = paused
+		switch {
+		case !w.started:
+			w.maybeStart()
+		case paused:
+			w.send("pause")
+		default:
+			w.send("start")
+		}
+	}
+}
+
+// maybeStart 条目已识别且时长已知时上报开始播放
+func (w *MPVWatcher) maybeStart() {
+	if w.req == nil || w.started || w.paused || w.duration <= 0 {
+		return
+	}
+	w.started = true
+	w.send("start")
+}
+
+// stop 上报停止播放（未开始时忽略）
+func (w *MPVWatcher) stop() {
+	if !w.started {
+		return
+	}
+	w.started = false
+	w.send("stop")
+}
+
+// send 以当前进度上报
+func (w *MPVWatcher) send(action string) {
+	w.req.Progress = progressPercent(w.position, w.duration)
+	send(w.Scrobbler, "mpv", action, *w.req)
+}
@@ -0,0 +1,116 @@
+package scrobbler
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+
+	"traktshow/trakt"
+)
+
+// fakeMPV 模拟 mpv 的 IPC socket：读取 observe_property 命令后依次发送事件，然后关闭连接
+func fakeMPV(t *testing.T, conn net.Conn, events []string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for range mpvProperties {
+		if _, err := reader.ReadString('\n'); err != nil {
+			t.Errorf("read command: %v", err)
+			return
+		}
+	}
+	for _, event := range events {
+		if _, err := fmt.Fprintln(conn, event); err != nil {
+			t.Errorf("write event: %v", err)
+			return
+		}
+	}
+}
+
+// runMPV 在 net.Pipe 上运行 MPVWatcher，返回上报的动作与进度
+func runMPV(t *testing.T, events []string) ([]string, []float64) {
+	t.Helper()
+	fake := &fakeScrobbler{}
+	w := &MPVWatcher{
+		Scrobbler: fake,
+		Identify: func(path string) (trakt.ScrobbleRequest, error) {
+			if path != "/media/Movie (2019).mkv" {
+				return trakt.ScrobbleRequest{}, fmt.Errorf("unknown file")
+			}
+			return trakt.ScrobbleRequest{Movie: &trakt.SyncItem{Title: "Movie", Year: 2019}}, nil
+		},
+	}
+	client, server := net.Pipe()
+	go fakeMPV(t, server, events)
+	if err := w.Watch(client); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []float64
+	for _, c := range fake.calls {
+		progress = append(progress, c.req.Progress)
+	}
+	return fake.actions(), progress
+}
+
+func TestMPVWatcher(t *testing.T) {
+	actions, progress := runMPV(t, []string{
+		`{"event":"property-change","id":4,"name":"pause","data":false}`,
+		`{"event":"property-change","id":1,"name":"path","data":"/media/Movie (2019).mkv"}`,
+		`{"event":"property-change","id":2,"name":"duration","data":6000}`,
+		`{"event":"property-change","id":3,"name":"time-pos","data":600}`,
+		`{"event":"property-change","id":4,"name":"pause","data":true}`,
+		`{"event":"property-change","id":4,"name":"pause","data":false}`,
+		`{"event":"property-change","id":3,"name":"time-pos","data":5700}`,
+		`{"event":"end-file","reason":"eof"}`,
+		`{"event":"property-change","id":1,"name":"path","data":null}`,
+	})
+	if want := []string{"start", "pause", "start", "stop"}; !slices.Equal(actions, want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+	if want := []float64{0, 10, 10, 100}; !slices.Equal(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestMPVWatcherDisconnect(t *testing.T) {
+	// 连接断开时以最后的进度上报 stop
+	actions, progress := runMPV(t, []string{
+		`{"event":"property-change","id":1,"name":"path","data":"/media/Movie (2019).mkv"}`,
+		`{"event":"property-change","id":2,"name":"duration","data":6000}`,
+		`{"event":"property-change","id":3,"name":"time-pos","data":3000}`,
+	})
+	if want := []string{"start", "stop"}; !slices.Equal(actions, want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+	if want := []float64{0, 50}; !slices.Equal(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestMPVWatcherUnidentified(t *testing.T) {
+	actions, _ := runMPV(t, []string{
+		`{"event":"property-change","id":1,"name":"path","data":"/media/unknown.mkv"}`,
+		`{"event":"property-change","id":2,"name":"duration","data":6000}`,
+		`{"event":"property-change","id":4,"name":"pause","data":true}`,
+		`{"event":"shutdown"}`,
+	})
+	if len(actions) != 0 {
+		t.Errorf("actions = %v, want none", actions)
+	}
+}
+
+func TestMPVWatcherStartPaused(t *testing.T) {
+	// 以暂停状态打开的文件在恢复播放时才上报 start
+	actions, _ := runMPV(t, []string{
+		`{"event":"property-change","id":4,"name":"pause","data":true}`,
+		`{"event":"property-change","id":1,"name":"path","data":"/media/Movie (2019).mkv"}`,
+		`{"event":"property-change","id":2,"name":"duration","data":6000}`,
+		`{"event":"property-change","id":4,"name":"pause","data":false}`,
+		`{"event":"end-file","reason":"stop"}`,
+	})
+	if want := []string{"start", "stop"}; !slices.Equal(actions, want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+}
@@ -0,0 +1,186 @@
+package scrobbler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// PlexPayload Plex Media Server webhook 的 payload
+type PlexPayload struct {
+	Event   string `json:"event"` // media.play、media.pause、media.resume、media.stop、media.scrobble 等
+	Account struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	} `json:"Account"`
+	Player struct {
+		Title string `json:"title"`
+		UUID  string `json:"uuid"`
+	} `json:"Player"`
+	Metadata struct {
+		Type             string `json:"type"` // movie、episode
+		Title            string `json:"title"`
+		GrandparentTitle string `json:"grandparentTitle"` // 单集所属剧集
+		GrandparentGUID  string `json:"grandparentGuid"`
+		ParentIndex      int    `json:"parentIndex"` // 季号
+		Index            int    `json:"index"`       // 集号
+		Year             int    `json:"year"`
+		GUID             string `json:"guid"`
+		GUIDs            []struct {
+			ID string `json:"id"`
+		} `json:"Guid"`
+		ViewOffset int64 `json:"viewOffset"` // 已播放时长（毫秒）
+		Duration   int64 `json:"duration"`   // 总时长（毫秒）
+	} `json:"Metadata"`
+}
+
+// 旧版 Plex 代理的 GUID（如 com.plexapp.agents.thetvdb://81189/1/2?lang=en）
+var legacyGUIDPattern = regexp.MustCompile(`^com\.plexapp\.agents\.(\w+)://([^/?]+)(?:/(\d+)/(\d+))?`)
+
+// ParsePlexPayload 解析 webhook 请求中的 payload JSON
+func ParsePlexPayload(data []byte) (*PlexPayload, error) {
+	var payload PlexPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("解析 Plex payload 失败：%v", err)
+	}
+	return &payload, nil
+}
+
+// ToScrobble 将 Plex 事件转换为 scrobble 动作与请求（不需要上报的事件 ok 为 false）
+func (p *PlexPayload) ToScrobble() (action string, req trakt.ScrobbleRequest, ok bool) {
+	switch p.Event {
+	case "media.play", "media.resume":
+		action = "start"
+	case "media.pause":
+		action = "pause"
+	case "media.stop", "media.scrobble":
+		action = "stop"
+	default:
+		return "", req, false
+	}
+
+	req.Progress = progressPercent(float64(p.Metadata.ViewOffset), float64(p.Metadata.Duration))
+	// media.scrobble 在播放超过 90% 时触发，payload 中的进度可能滞后
+	if p.Event == "media.scrobble" {
+		req.Progress = max(req.Progress, 90)
+	}
+
+	ids, season, episode := ParsePlexGUIDs(p.Metadata.GUID, p.guidList())
+	switch p.Metadata.Type {
+	case "movie":
+		req.Movie = &trakt.SyncItem{IDs: ids, Title: p.Metadata.Title, Year: p.Metadata.Year}
+	case "episode":
+		// 新版代理提供单集自身的ID；旧版 TVDB 代理的 GUID 为剧集ID + 季号/集号
+		if season > 0 || ids == (trakt.TraktIDs{}) {
+			showIDs := ids
+			if season == 0 {
+				showIDs, _, _ = ParsePlexGUIDs(p.Metadata.GrandparentGUID, nil)
+			}
+			if season == 0 {
+				season, episode = p.Metadata.ParentIndex, p.Metadata.Index
+			}
+			req.Show = &trakt.SyncItem{IDs: showIDs, Title: p.Metadata.GrandparentTitle}
+			req.Episode = &trakt.ScrobbleEpisode{Season: season, Number: episode}
+		} else {
+			req.Episode = &trakt.ScrobbleEpisode{IDs: &ids}
+		}
+	default:
+		return "", req, false
+	}
+	return action, req, true
+}
+
+// guidList 新版 Plex 代理的外部ID列表
+func (p *PlexPayload) guidList() []string {
+	var list []string
+	for _, g := range p.Metadata.GUIDs {
+		list = append(list, g.ID)
+	}
+	return list
+}
+
+// ParsePlexGUIDs 从 Plex GUID 中提取 IMDb/TMDb/TVDB ID
+// guids 为新版代理的 Guid 列表（如 imdb://tt123、tmdb://456、tvdb://789）；旧版代理的 TVDB GUID 会同时返回季号与集号
+func ParsePlexGUIDs(guid string, guids []string) (ids trakt.TraktIDs, season, episode int) {
+	for _, g := range guids {
+		scheme, value, ok := strings.Cut(g, "://")
+		if !ok {
+			continue
+		}
+		setID(&ids, scheme, value)
+	}
+
+	if m := legacyGUIDPattern.FindStringSubmatch(guid); m != nil {
+		setID(&ids, m[1], m[2])
+		if m[3] != "" {
+			season, _ = strconv.Atoi(m[3])
+			episode, _ = strconv.Atoi(m[4])
+		}
+	}
+	return ids, season, episode
+}
+
+// setID 按ID来源设置对应字段
+func setID(ids *trakt.TraktIDs, scheme, value string) {
+	switch scheme {
+	case "imdb":
+		ids.IMDB = value
+	case "tmdb", "themoviedb":
+		ids.TMDb, _ = strconv.Atoi(value)
+	case "tvdb", "thetvdb":
+		ids.TVDB, _ = strconv.Atoi(value)
+	}
+}
+
+// PlexHandler 接收 Plex webhook 并上报到 Trakt
+type PlexHandler struct {
+	Scrobbler Scrobbler
+	Users     []string // 只处理这些 Plex 账号的事件（为空时处理全部）
+	dedup     Dedup
+}
+
+// ServeHTTP 处理 webhook 请求（multipart 表单中的 payload 字段）
+func (h *PlexHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		http.Error(w, "invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	payload, err := ParsePlexPayload([]byte(r.FormValue("payload")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(h.Users) > 0 && !slices.Contains(h.Users, payload.Account.Title) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	action, req, ok := payload.ToScrobble()
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	session := payload.Player.UUID + "|" + payload.Metadata.GUID
+	if !h.dedup.Allow(session, action) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	log.Printf("[plex] %s：%s %s", payload.Account.Title, payload.Event, payload.Metadata.Title)
+	if err := send(h.Scrobbler, "plex", action, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
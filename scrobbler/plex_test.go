@@ -0,0 +1,177 @@
+package scrobbler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"traktshow/trakt"
+)
+
+// readPlexPayload 读取 testdata 中录制的 webhook payload
+func readPlexPayload(t *testing.T, file string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// plexRequest 按 Plex 的格式将 payload 放入 multipart 表单
+func plexRequest(t *testing.T, payload []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("payload", string(payload)); err != nil {
+		t.Fatal(err)
+	}
+	mw.Close()
+	r := httptest.NewRequest(http.MethodPost, "/plex", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestParsePlexPayload(t *testing.T) {
+	p, err := ParsePlexPayload(readPlexPayload(t, "plex_episode_scrobble.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Event != "media.scrobble" || p.Account.Title != "alice" || p.Player.UUID != "player-bedroom" {
+		t.Errorf("payload = %+v", p)
+	}
+	m := p.Metadata
+	if m.Type != "episode" || m.GrandparentTitle != "Breaking Bad" || m.ParentIndex != 5 || m.Index != 14 ||
+		m.ViewOffset != 2400000 || m.Duration != 2820000 || len(m.GUIDs) != 3 {
+		t.Errorf("metadata = %+v", m)
+	}
+
+	if _, err := ParsePlexPayload([]byte("not json")); err == nil {
+		t.Error("invalid JSON: want error")
+	}
+}
+
+func TestParsePlexGUIDs(t *testing.T) {
+	tests := []struct {
+		name            string
+		guid            string
+		guids           []string
+		want            trakt.TraktIDs
+		season, episode int
+	}{
+		{"new agent", "plex://movie/5d776825880197001ec967c6", []string{"imdb://tt0137523", "tmdb://550", "tvdb://340"},
+			trakt.TraktIDs{IMDB: "tt0137523", TMDb: 550, TVDB: 340}, 0, 0},
+		{"legacy imdb", "com.plexapp.agents.imdb://tt0137523?lang=en", nil,
+			trakt.TraktIDs{IMDB: "tt0137523"}, 0, 0},
+		{"legacy themoviedb", "com.plexapp.agents.themoviedb://550?lang=en", nil,
+			trakt.TraktIDs{TMDb: 550}, 0, 0},
+		{"legacy tvdb episode", "com.plexapp.agents.thetvdb://81189/3/7?lang=en", nil,
+			trakt.TraktIDs{TVDB: 81189}, 3, 7},
+		{"legacy tvdb show", "com.plexapp.agents.thetvdb://81189?lang=en", nil,
+			trakt.TraktIDs{TVDB: 81189}, 0, 0},
+		{"local media", "local://12345", []string{"invalid"}, trakt.TraktIDs{}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, season, episode := ParsePlexGUIDs(tt.guid, tt.guids)
+			if ids != tt.want || season != tt.season || episode != tt.episode {
+				t.Errorf("ParsePlexGUIDs() = %+v, %d, %d; want %+v, %d, %d", ids, season, episode, tt.want, tt.season, tt.episode)
+			}
+		})
+	}
+}
+
+func TestToScrobble(t *testing.T) {
+	tests := []struct {
+		file     string
+		action   string
+		ok       bool
+		progress float64
+		check    func(req trakt.ScrobbleRequest) bool
+	}{
+		{"plex_movie_play.json", "start", true, 10, func(req trakt.ScrobbleRequest) bool {
+			return req.Movie != nil && req.Movie.IDs == trakt.TraktIDs{IMDB: "tt0137523", TMDb: 550, TVDB: 340} &&
+				req.Movie.Title == "Fight Club" && req.Movie.Year == 1999
+		}},
+		// media.scrobble 的进度至少为 90%
+		{"plex_episode_scrobble.json", "stop", true, 90, func(req trakt.ScrobbleRequest) bool {
+			return req.Show == nil && req.Episode != nil && req.Episode.IDs != nil &&
+				*req.Episode.IDs == trakt.TraktIDs{IMDB: "tt2301451", TMDb: 62161, TVDB: 4639433}
+		}},
+		{"plex_legacy_episode_pause.json", "pause", true, 50, func(req trakt.ScrobbleRequest) bool {
+			return req.Show != nil && req.Show.IDs == trakt.TraktIDs{TVDB: 81189} && req.Show.Title == "Breaking Bad" &&
+				req.Episode != nil && req.Episode.IDs == nil && req.Episode.Season == 3 && req.Episode.Number == 7
+		}},
+		{"plex_library_new.json", "", false, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			p, err := ParsePlexPayload(readPlexPayload(t, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			action, req, ok := p.ToScrobble()
+			if action != tt.action || ok != tt.ok {
+				t.Fatalf("ToScrobble() = %q, %v; want %q, %v", action, ok, tt.action, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if req.Progress < tt.progress-0.01 || req.Progress > tt.progress+0.01 {
+				t.Errorf("progress = %.2f, want %.2f", req.Progress, tt.progress)
+			}
+			if !tt.check(req) {
+				t.Errorf("request = %+v", req)
+			}
+		})
+	}
+}
+
+func TestPlexHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		users []string
+		want  []string
+	}{
+		{"all users", "plex_movie_play.json", nil, []string{"start"}},
+		{"matching user", "plex_movie_play.json", []string{"alice"}, []string{"start"}},
+		{"other user", "plex_legacy_episode_pause.json", []string{"alice"}, nil},
+		{"not a playback event", "plex_library_new.json", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeScrobbler{}
+			h := &PlexHandler{Scrobbler: fake, Users: tt.users}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, plexRequest(t, readPlexPayload(t, tt.file)))
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			if got := fake.actions(); !slices.Equal(got, tt.want) {
+				t.Errorf("actions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 同一播放器重复的事件只上报一次
+	fake := &fakeScrobbler{}
+	h := &PlexHandler{Scrobbler: fake}
+	payload := readPlexPayload(t, "plex_movie_play.json")
+	for range 2 {
+		h.ServeHTTP(httptest.NewRecorder(), plexRequest(t, payload))
+	}
+	if got := fake.actions(); !slices.Equal(got, []string{"start"}) {
+		t.Errorf("duplicate events: actions = %v", got)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/plex", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d", w.Code)
+	}
+}
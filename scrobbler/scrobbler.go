@@ -0,0 +1,176 @@
+package scrobbler
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+)
+
+// Scrobbler 上报播放状态的接口（便于在测试中替换为记录调用的实现）
+type Scrobbler interface {
+	Scrobble(action string, req trakt.ScrobbleRequest) (*trakt.ScrobbleResult, error)
+	MarkWatched(req trakt.ScrobbleRequest, watchedAt time.Time) error
+}
+
+// TraktScrobbler 通过 Trakt scrobble 接口上报播放状态（每次上报时重新加载令牌，适合长时间运行的服务）
+type TraktScrobbler struct {
+	LoadToken func() (*oauth2.Token, error)
+}
+
+// Scrobble 按动作（start、pause、stop）上报播放状态
+func (s *TraktScrobbler) Scrobble(action string, req trakt.ScrobbleRequest) (*trakt.ScrobbleResult, error) {
+	token, err := s.LoadToken()
+	if err != nil {
+		return nil, fmt.Errorf("加载令牌失败：%v", err)
+	}
+	switch action {
+	case "start":
+		return trakt.ScrobbleStart(token, req)
+	case "pause":
+		return trakt.ScrobblePause(token, req)
+	case "stop":
+		return trakt.ScrobbleStop(token, req)
+	}
+	return nil, fmt.Errorf("未知的 scrobble 动作：%s", action)
+}
+
+// MarkWatched 将条目直接写入观看记录（用于进度达到自定义阈值但不足 Trakt 的80%时）
+func (s *TraktScrobbler) MarkWatched(req trakt.ScrobbleRequest, watchedAt time.Time) error {
+	token, err := s.LoadToken()
+	if err != nil {
+		return fmt.Errorf("加载令牌失败：%v", err)
+	}
+	if err := resolveIDs(token, &req); err != nil {
+		return err
+	}
+	items := historyItems(req, watchedAt)
+	if items.Len() == 0 {
+		return fmt.Errorf("条目没有可用的ID，已跳过写入观看记录")
+	}
+	_, err = trakt.AddToHistory(token, items)
+	return err
+}
+
+// resolveIDs 为只有标题与年份的电影/剧集搜索ID（sync 接口不支持按标题匹配）
+// 只接受标题（及年份）唯一匹配的结果，否则返回错误，不写入可能错误的条目
+func resolveIDs(token *oauth2.Token, req *trakt.ScrobbleRequest) error {
+	var item **trakt.SyncItem
+	var kind string
+	switch {
+	case req.Movie != nil && req.Movie.IDs == (trakt.TraktIDs{}):
+		item, kind = &req.Movie, "movie"
+	case req.Show != nil && req.Show.IDs == (trakt.TraktIDs{}):
+		item, kind = &req.Show, "show"
+	default:
+		return nil
+	}
+	title, year := (*item).Title, (*item).Year
+	if title == "" {
+		return fmt.Errorf("条目没有ID与标题，已跳过写入观看记录")
+	}
+
+	results, err := trakt.Search(token, []string{kind}, title, trakt.SearchOptions{Limit: 10})
+	if err != nil {
+		return err
+	}
+	var match *trakt.TraktSearchResult
+	for i := range results {
+		r := &results[i]
+		if !strings.EqualFold(r.Title(), title) || year > 0 && r.Year() != year {
+			continue
+		}
+		if match != nil {
+			return fmt.Errorf("「%s」匹配到多个条目，已跳过写入观看记录", title)
+		}
+		match = r
+	}
+	if match == nil {
+		return fmt.Errorf("未找到「%s」，已跳过写入观看记录", title)
+	}
+	resolved := **item
+	resolved.IDs = match.IDs()
+	*item = &resolved
+	return nil
+}
+
+// historyItems 将 scrobble 请求转换为观看记录的提交内容（没有ID的条目不提交）
+func historyItems(req trakt.ScrobbleRequest, watchedAt time.Time) trakt.SyncItems {
+	at := watchedAt.UTC().Format(time.RFC3339)
+	var items trakt.SyncItems
+	switch {
+	case req.Movie != nil && req.Movie.IDs != (trakt.TraktIDs{}):
+		items.Movies = append(items.Movies, trakt.SyncItem{IDs: req.Movie.IDs, WatchedAt: at})
+	case req.Episode != nil && req.Episode.IDs != nil:
+		items.Episodes = append(items.Episodes, trakt.SyncItem{IDs: *req.Episode.IDs, WatchedAt: at})
+	case req.Show != nil && req.Show.IDs != (trakt.TraktIDs{}) && req.Episode != nil:
+		show := trakt.SyncItem{IDs: req.Show.IDs}
+		show.Seasons = []trakt.SyncSeason{{
+			Number:   req.Episode.Season,
+			Episodes: []trakt.SyncEpisode{{Number: req.Episode.Number, WatchedAt: at}},
+		}}
+		items.Shows = append(items.Shows, show)
+	}
+	return items
+}
+
+// finish 处理播放结束：进度未达到阈值时仅保存播放进度；达到阈值时标记为已观看
+// Trakt 的 scrobble stop 只在进度不低于80%时记为已观看，阈值更低时仍发送 stop 结束正在观看的状态，再直接写入观看记录
+func finish(s Scrobbler, source string, req trakt.ScrobbleRequest, minProgress float64) error {
+	switch {
+	case req.Progress < minProgress:
+		return send(s, source, "pause", req)
+	case req.Progress >= 80:
+		return send(s, source, "stop", req)
+	}
+	stopErr := send(s, source, "stop", req)
+	if err := s.MarkWatched(req, time.Now()); err != nil {
+		log.Printf("❌ [%s] 写入观看记录失败：%v", source, err)
+		return err
+	}
+	log.Printf("✅ [%s] 进度 %.1f%% 已达到阈值，已写入观看记录", source, req.Progress)
+	return stopErr
+}
+
+// Dedup 过滤重复的上报（同一会话连续相同的动作只上报一次）
+type Dedup struct {
+	mu   sync.Mutex
+	last map[string]string // 会话键 → 上次动作
+}
+
+// Allow 判断本次上报是否需要发送
+func (d *Dedup) Allow(session, action string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.last == nil {
+		d.last = make(map[string]string)
+	}
+	if d.last[session] == action {
+		return false
+	}
+	d.last[session] = action
+	return true
+}
+
+// send 上报并记录日志
+func send(s Scrobbler, source, action string, req trakt.ScrobbleRequest) error {
+	result, err := s.Scrobble(action, req)
+	if err != nil {
+		log.Printf("❌ [%s] scrobble %s 失败：%v", source, action, err)
+		return err
+	}
+	log.Printf("✅ [%s] scrobble %s → %s（进度 %.1f%%）", source, action, result.Action, result.Progress)
+	return nil
+}
+
+// progressPercent 根据已播放时长与总时长计算进度百分比（限制在 0-100）
+func progressPercent(position, duration float64) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return min(max(position*100/duration, 0), 100)
+}
@@ -0,0 +1,122 @@
+package scrobbler
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"traktshow/trakt"
+)
+
+// call fakeScrobbler 记录的一次调用（action 为 watched 表示 MarkWatched）
+type call struct {
+	action string
+	req    trakt.ScrobbleRequest
+}
+
+// fakeScrobbler 记录调用而不请求 Trakt 的 Scrobbler
+type fakeScrobbler struct {
+	mu    sync.Mutex
+	calls []call
+}
+
+func (f *fakeScrobbler) Scrobble(action string, req trakt.ScrobbleRequest) (*trakt.ScrobbleResult, error) {
+	f.record(action, req)
+	return &trakt.ScrobbleResult{Action: action, Progress: req.Progress}, nil
+}
+
+func (f *fakeScrobbler) MarkWatched(req trakt.ScrobbleRequest, watchedAt time.Time) error {
+	f.record("watched", req)
+	return nil
+}
+
+func (f *fakeScrobbler) record(action string, req trakt.ScrobbleRequest) {
+	f.mu.Lock()
+	f.calls = append(f.calls, call{action, req})
+	f.mu.Unlock()
+}
+
+// actions 已记录调用的动作序列
+func (f *fakeScrobbler) actions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var actions []string
+	for _, c := range f.calls {
+		actions = append(actions, c.action)
+	}
+	return actions
+}
+
+func TestFinish(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress float64
+		want     []string
+	}{
+		{"below threshold", 40, []string{"pause"}},
+		{"between threshold and 80", 60, []string{"stop", "watched"}},
+		{"at threshold", 50, []string{"stop", "watched"}},
+		{"at 80", 80, []string{"stop"}},
+		{"complete", 100, []string{"stop"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeScrobbler{}
+			req := trakt.ScrobbleRequest{Movie: &trakt.SyncItem{IDs: trakt.TraktIDs{IMDB: "tt0111161"}}, Progress: tt.progress}
+			if err := finish(fake, "test", req, 50); err != nil {
+				t.Fatal(err)
+			}
+			if got := fake.actions(); !slices.Equal(got, tt.want) {
+				t.Errorf("actions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryItems(t *testing.T) {
+	at := time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)
+	ids := trakt.TraktIDs{TVDB: 123}
+	items := historyItems(trakt.ScrobbleRequest{Episode: &trakt.ScrobbleEpisode{IDs: &ids}}, at)
+	if len(items.Episodes) != 1 || items.Episodes[0].IDs != ids || items.Episodes[0].WatchedAt != "2024-03-15T20:00:00Z" {
+		t.Errorf("episode by ID = %+v", items)
+	}
+
+	items = historyItems(trakt.ScrobbleRequest{
+		Show:    &trakt.SyncItem{Title: "Show Name", IDs: trakt.TraktIDs{Trakt: 1}},
+		Episode: &trakt.ScrobbleEpisode{Season: 2, Number: 5},
+	}, at)
+	if len(items.Shows) != 1 || len(items.Shows[0].Seasons) != 1 || items.Shows[0].Title != "" ||
+		items.Shows[0].Seasons[0].Number != 2 || items.Shows[0].Seasons[0].Episodes[0].Number != 5 {
+		t.Errorf("episode by show = %+v", items)
+	}
+
+	// 只有标题的条目不提交（sync 接口不按标题匹配）
+	for _, req := range []trakt.ScrobbleRequest{
+		{Movie: &trakt.SyncItem{Title: "Movie", Year: 2019}},
+		{Show: &trakt.SyncItem{Title: "Show Name"}, Episode: &trakt.ScrobbleEpisode{Season: 2, Number: 5}},
+	} {
+		if items := historyItems(req, at); items.Len() != 0 {
+			t.Errorf("title-only request = %+v, want no items", items)
+		}
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var d Dedup
+	steps := []struct {
+		session, action string
+		want            bool
+	}{
+		{"a", "start", true},
+		{"a", "start", false},
+		{"b", "start", true},
+		{"a", "pause", true},
+		{"a", "start", true},
+	}
+	for _, s := range steps {
+		if got := d.Allow(s.session, s.action); got != s.want {
+			t.Errorf("Allow(%q, %q) = %v, want %v", s.session, s.action, got, s.want)
+		}
+	}
+}
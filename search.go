@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runSearch 按关键词或ID搜索电影、剧集、单集和人物
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	types := fs.String("type", "movie,show", "搜索类型（逗号分隔）：movie、show、episode、person")
+	years := fs.String("year", "", "年份或年份范围（如 2019 或 2010-2019）")
+	fields := fs.String("fields", "", "搜索字段（逗号分隔）：title、overview、aliases、original_title、translations、tagline、people、name 等")
+	limit := fs.Int("limit", 10, "返回结果数")
+	id := fs.String("id", "", "按ID查找：tt开头的IMDb ID、tmdb:123、tvdb:123、trakt:123 或 slug:xxx")
+	fs.Parse(args)
+
+	var results []trakt.TraktSearchResult
+	var err error
+	if *id != "" {
+		idType, value, ok := parseIDRef(*id)
+		if !ok {
+			return fmt.Errorf("无法识别的ID：%s", *id)
+		}
+		// 指定单一类型时按该类型过滤
+		itemType := ""
+		if !strings.Contains(*types, ",") {
+			itemType = *types
+		}
+		results, err = trakt.LookupID(accessToken, idType, value, itemType)
+	} else {
+		query := strings.Join(fs.Args(), " ")
+		if query == "" {
+			return fmt.Errorf("请输入搜索关键词或通过 -id 指定ID")
+		}
+		opts := trakt.SearchOptions{Years: *years, Limit: *limit}
+		if *fields != "" {
+			opts.Fields = strings.Split(*fields, ",")
+		}
+		results, err = trakt.Search(accessToken, strings.Split(*types, ","), query, opts)
+	}
+	if err != nil {
+		return err
+	}
+	utils.PrintSearchResults(results)
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"traktshow/dashboard"
+	"traktshow/feed"
+	"traktshow/metrics"
+	"traktshow/scrobbler"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runServe 以服务模式运行（plex 接收 Plex webhook，jellyfin/emby 接收播放通知，web 提供仪表盘页面，metrics 提供 Prometheus 指标）
+func runServe(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法：serve plex|jellyfin|emby|web|metrics [参数]")
+	}
+	switch args[0] {
+	case "plex":
+		return servePlex(args[1:])
+	case "jellyfin", "emby":
+		return serveMediaServer(args[0], args[1:])
+	case "web":
+		return serveWeb(args[1:])
+	case "metrics":
+		return serveMetrics(args[1:])
+	default:
+		return fmt.Errorf("未知的服务：%s", args[0])
+	}
+}
+
+// servePlex 接收 Plex Media Server webhook 并上报到 Trakt
+func servePlex(args []string) error {
+	fs := flag.NewFlagSet("serve plex", flag.ExitOnError)
+	addr := fs.String("addr", ":8765", "监听地址")
+	path := fs.String("path", "/plex", "webhook 路径")
+	users := fs.String("user", "", "只处理这些 Plex 账号的事件（逗号分隔，为空时处理全部）")
+	fs.Parse(args)
+
+	handler := &scrobbler.PlexHandler{
+		Scrobbler: &scrobbler.TraktScrobbler{LoadToken: utils.LoadToken},
+		Users:     splitList(*users),
+	}
+	mux := http.NewServeMux()
+	mux.Handle(*path, handler)
+
+	log.Printf("🎬 Plex webhook 已启动：http://%s%s", *addr, *path)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// serveMediaServer 接收 Jellyfin Webhook 插件或 Emby 的播放通知并上报到 Trakt（两种格式可共用同一地址）
+func serveMediaServer(name string, args []string) error {
+	fs := flag.NewFlagSet("serve "+name, flag.ExitOnError)
+	addr := fs.String("addr", ":8766", "监听地址")
+	path := fs.String("path", "/"+name, "webhook 路径")
+	users := fs.String("user", "", "只处理这些用户的事件（逗号分隔，为空时处理全部）")
+	minProgress := fs.Float64("min-progress", 80, "停止播放时标记为已观看所需的最低进度百分比")
+	fs.Parse(args)
+
+	if *minProgress <= 0 || *minProgress > 100 {
+		return fmt.Errorf("最低进度必须在 0-100 之间")
+	}
+	handler := &scrobbler.MediaServerHandler{
+		Scrobbler:   &scrobbler.TraktScrobbler{LoadToken: utils.LoadToken},
+		Users:       splitList(*users),
+		MinProgress: *minProgress,
+	}
+	mux := http.NewServeMux()
+	mux.Handle(*path, handler)
+
+	log.Printf("🎬 %s webhook 已启动：http://%s%s（最低进度 %.0f%%）", name, *addr, *path, *minProgress)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// serveWeb 提供本地观影仪表盘与观看记录订阅源（数据与命令行共用本地缓存）
+func serveWeb(args []string) error {
+	fs := flag.NewFlagSet("serve web", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "监听地址（局域网访问可使用 :8080）")
+	maxAge := fs.Duration("max-age", 15*time.Minute, "缓存有效期，超过后打开页面时重新获取")
+	fs.Parse(args)
+
+	feeds := &feed.Handler{Source: &feed.Source{LoadToken: utils.LoadToken, MaxAge: *maxAge, Limit: 50}}
+	mux := http.NewServeMux()
+	mux.Handle("/", &dashboard.Handler{Source: &dashboard.Source{LoadToken: utils.LoadToken, MaxAge: *maxAge}})
+	mux.Handle("/history.rss", feeds)
+	mux.Handle("/history.atom", feeds)
+	log.Printf("📊 仪表盘已启动：http://%s/（订阅源：/history.rss、/history.atom）", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// serveMetrics 提供 Prometheus 指标（观看习惯与 Trakt 接口调用情况）
+func serveMetrics(args []string) error {
+	fs := flag.NewFlagSet("serve metrics", flag.ExitOnError)
+	addr := fs.String("addr", ":9788", "监听地址")
+	interval := fs.Duration("interval", 15*time.Minute, "观看指标的刷新间隔")
+	fs.Parse(args)
+
+	api := metrics.NewAPIMetrics()
+	trakt.OnRequest = api.Observe
+	viewing := &metrics.Viewing{LoadToken: utils.LoadToken, Interval: *interval}
+	go viewing.Run()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", &metrics.Handler{API: api, Viewing: viewing})
+	log.Printf("📈 指标服务已启动：http://%s/metrics", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// splitList 解析逗号分隔的列表（忽略空白项）
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"traktshow/recommender"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runSuggest 基于本地口味画像推荐电影或剧集（数据优先取自本地缓存）
+func runSuggest(args []string) error {
+	fs := flag.NewFlagSet("suggest", flag.ExitOnError)
+	mediaType := fs.String("type", "movies", "推荐类型：movies 或 shows")
+	limit := fs.Int("limit", 10, "推荐条目数")
+	seeds := fs.Int("seeds", 5, "用于获取相关条目的高分作品数")
+	refresh := fs.Bool("refresh", false, "忽略缓存，重新从 Trakt 获取数据")
+	maxAge := fs.Duration("max-age", 24*time.Hour, "缓存的有效期（超过时重新从 Trakt 获取）")
+	offline := fs.Bool("offline", false, "仅使用本地缓存（缓存不存在时报错）")
+	fs.Parse(args)
+
+	if *mediaType != "movies" && *mediaType != "shows" {
+		return fmt.Errorf("不支持的推荐类型：%s", *mediaType)
+	}
+	if *offline && *refresh {
+		return fmt.Errorf("-offline 与 -refresh 不能同时使用")
+	}
+	if *maxAge <= 0 {
+		return fmt.Errorf("缓存有效期必须大于0")
+	}
+	cache := cacheOptions{maxAge: *maxAge, refresh: *refresh, offline: *offline}
+
+	movies, err := cachedFetch("watched_movies", cache, func() ([]trakt.TraktWatchedMovie, error) {
+		return trakt.GetWatchedMovies(accessToken)
+	})
+	if err != nil {
+		return err
+	}
+	shows, err := cachedFetch("watched_shows", cache, func() ([]trakt.TraktWatchedShow, error) {
+		return trakt.GetWatchedShows(accessToken)
+	})
+	if err != nil {
+		return err
+	}
+	ratings, err := cachedFetch("ratings", cache, func() ([]trakt.TraktRating, error) {
+		return trakt.GetRatings(accessToken, "")
+	})
+	if err != nil {
+		return err
+	}
+	profile := recommender.BuildProfile(movies, shows, ratings)
+
+	candidates, err := cachedFetch("candidates_"+*mediaType, cache, func() ([]trakt.DiscoverItem, error) {
+		return fetchCandidates(*mediaType, seedIDs(*mediaType, ratings, *seeds))
+	})
+	if err != nil {
+		return err
+	}
+
+	recommendations := profile.Score(candidates)
+	if len(recommendations) > *limit {
+		recommendations = recommendations[:*limit]
+	}
+	utils.PrintSuggestions(profile, recommendations)
+	return nil
+}
+
+// cacheOptions 推荐数据的缓存选项
+type cacheOptions struct {
+	maxAge  time.Duration // 缓存有效期
+	refresh bool          // 忽略缓存
+	offline bool          // 仅使用本地缓存（不检查有效期）
+}
+
+// cachedFetch 读取本地缓存（超过有效期时重新获取；offline 时缓存不存在直接报错，不访问网络）
+func cachedFetch[T any](name string, opts cacheOptions, fetch func() (T, error)) (T, error) {
+	switch {
+	case opts.offline:
+		var data T
+		if _, err := utils.LoadCache(name, &data); err != nil {
+			return data, fmt.Errorf("离线模式下缺少本地缓存：%s（请先不带 -offline 运行一次）", name)
+		}
+		return data, nil
+	case opts.refresh:
+		return utils.Cached(name, true, fetch)
+	}
+	return utils.CachedWithin(name, opts.maxAge, fetch)
+}
+
+// fetchCandidates 从发现榜单与高分作品的相关条目中收集候选
+func fetchCandidates(mediaType string, seeds []int) ([]trakt.DiscoverItem, error) {
+	var candidates []trakt.DiscoverItem
+	for _, feed := range []string{"trending", "popular", "anticipated"} {
+		items, err := trakt.Discover(accessToken, mediaType, feed, trakt.DiscoverOptions{Limit: 50})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, items...)
+	}
+	for _, id := range seeds {
+		items, err := trakt.GetRelated(accessToken, mediaType, fmt.Sprint(id), 20)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, items...)
+	}
+	return candidates, nil
+}
+
+// seedIDs 选取评分最高的若干作品作为相关推荐的种子
+func seedIDs(mediaType string, ratings []trakt.TraktRating, limit int) []int {
+	itemType := "movie"
+	if mediaType == "shows" {
+		itemType = "show"
+	}
+
+	var top []trakt.TraktRating
+	for _, r := range ratings {
+		if r.Type == itemType && r.Rating >= 8 {
+			top = append(top, r)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Rating > top[j].Rating
+	})
+
+	var ids []int
+	for i := 0; i < len(top) && i < limit; i++ {
+		ids = append(ids, top[i].TraktID())
+	}
+	return ids
+}
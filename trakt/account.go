@@ -0,0 +1,75 @@
+package trakt
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktPlayback 未看完的播放进度（/sync/playback）
+type TraktPlayback struct {
+	ID       int64         `json:"id"`
+	Progress float64       `json:"progress"`
+	PausedAt time.Time     `json:"paused_at"`
+	Type     string        `json:"type"`
+	Movie    *TraktMovie   `json:"movie,omitempty"`
+	Show     *TraktShow    `json:"show,omitempty"`
+	Episode  *TraktEpisode `json:"episode,omitempty"`
+}
+
+// GetUserProfile 获取当前用户的完整资料（原始JSON）
+func GetUserProfile(token *oauth2.Token) (json.RawMessage, error) {
+	var profile json.RawMessage
+	if _, err := doRequest(token, http.MethodGet, "/users/me?extended=full", nil, &profile); err != nil {
+		return nil, fmt.Errorf("获取用户资料失败：%v", err)
+	}
+	return profile, nil
+}
+
+// GetCurrentUser 获取当前令牌对应的用户信息
+func GetCurrentUser(token *oauth2.Token) (*TraktUserInfo, error) {
+	var info TraktUserInfo
+	if _, err := doRequest(token, http.MethodGet, "/users/me", nil, &info); err != nil {
+		return nil, fmt.Errorf("获取用户信息失败：%v", err)
+	}
+	return &info, nil
+}
+
+// GetUserSettings 获取当前用户的账号设置（原始JSON）
+func GetUserSettings(token *oauth2.Token) (json.RawMessage, error) {
+	var settings json.RawMessage
+	if _, err := doRequest(token, http.MethodGet, "/users/settings", nil, &settings); err != nil {
+		return nil, fmt.Errorf("获取账号设置失败：%v", err)
+	}
+	return settings, nil
+}
+
+// GetPlayback 获取所有未看完的播放进度
+func GetPlayback(token *oauth2.Token) ([]TraktPlayback, error) {
+	var playback []TraktPlayback
+	if _, err := doRequest(token, http.MethodGet, "/sync/playback?extended=full", nil, &playback); err != nil {
+		return nil, fmt.Errorf("获取播放进度失败：%v", err)
+	}
+	return playback, nil
+}
+
+// GetComments 获取当前用户发表的全部评论与回复（原始JSON）
+func GetComments(token *oauth2.Token) ([]json.RawMessage, error) {
+	comments, err := getAllPages[json.RawMessage](token, "/users/me/comments/all/all?include_replies=true")
+	if err != nil {
+		return nil, fmt.Errorf("获取评论失败：%v", err)
+	}
+	return comments, nil
+}
+
+// AddHiddenItems 在指定分区中隐藏条目（section：calendar、progress_watched、progress_collected、recommendations、dropped 等）
+func AddHiddenItems(token *oauth2.Token, section string, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/users/hidden/"+section, items, &result); err != nil {
+		return nil, fmt.Errorf("隐藏条目失败：%v", err)
+	}
+	return &result, nil
+}
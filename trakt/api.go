@@ -3,9 +3,12 @@ package trakt
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"traktshow/config"
@@ -20,7 +23,10 @@ type TraktUserInfo struct {
 	Name     string `json:"name"`
 	JoinedAt string `json:"joined_at"`
 	Location string `json:"location"`
-	Stats    struct {
+	IDs      struct {
+		Slug string `json:"slug"`
+	} `json:"ids"`
+	Stats struct {
 		Movies struct {
 			Watched int `json:"watched"`
 		} `json:"movies"`
@@ -32,6 +38,7 @@ type TraktUserInfo struct {
 
 // TraktWatchHistoryItem 观看记录结构体
 type TraktWatchHistoryItem struct {
+	ID        int64     `json:"id"`
 	WatchedAt time.Time `json:"watched_at"`
 	Action    string    `json:"action"`
 	Type      string    `json:"type"`
@@ -98,9 +105,8 @@ type TraktWatchHistoryItem struct {
 			Votes        int    `json:"votes"`
 		} `json:"episode"`
 	} `json:"show"`
-	Movie *struct {
-		// 暂时不处理电影，因为您的数据中没有电影
-	} `json:"movie"`
+	Episode *TraktEpisode `json:"episode"`
+	Movie   *TraktMovie   `json:"movie"`
 }
 
 // GetOAuthConfig 获取OAuth2基础配置（仅用于生成授权URL）
@@ -271,4 +277,114 @@ func GetWatchHistory(token *oauth2.Token, limit int) ([]TraktWatchHistoryItem, e
 	}
 
 	return history, nil
-}
\ No newline at end of file
+}
+// APIError API请求返回的非2xx响应
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API请求失败：%s %s 状态码 %d（响应内容：%s）", e.Method, e.Path, e.StatusCode, string(e.Body))
+}
+
+// isNotFound 判断错误是否为 404 响应
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// RequestInfo 单次API请求的结果（供监控统计使用）
+type RequestInfo struct {
+	Method     string
+	Path       string // 请求路径（含查询参数）
+	StatusCode int    // 网络错误时为0
+	Duration   time.Duration
+	Header     http.Header // 响应头（网络错误时为 nil）
+}
+
+// OnRequest 每次通过 doRequest 发送的请求完成后调用（为 nil 时不调用）
+var OnRequest func(info RequestInfo)
+
+// doRequest 发送带令牌认证的API请求，并将JSON响应解析到out（token为nil时按公开接口请求，out为nil时忽略响应体）
+func doRequest(token *oauth2.Token, method, path string, body interface{}, out interface{}) (http.Header, error) {
+	cfg := config.Get()
+
+	// 构造请求体
+	var reader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("构造请求体失败：%v", err)
+		}
+		reader = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequest(method, traktAPIEndpoint+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败：%v", err)
+	}
+
+	// 设置必需请求头
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("trakt-api-version", "2")
+	req.Header.Set("trakt-api-key", cfg.ClientID)
+	if token != nil {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if OnRequest != nil {
+			OnRequest(RequestInfo{Method: method, Path: path, Duration: time.Since(start)})
+		}
+		return nil, fmt.Errorf("发送请求失败：%v", err)
+	}
+	defer resp.Body.Close()
+
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	if OnRequest != nil {
+		OnRequest(RequestInfo{Method: method, Path: path, StatusCode: resp.StatusCode, Duration: time.Since(start), Header: resp.Header})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败：%v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.Header, &APIError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: respBodyBytes}
+	}
+
+	if out != nil && len(respBodyBytes) > 0 {
+		if err := json.Unmarshal(respBodyBytes, out); err != nil {
+			return resp.Header, fmt.Errorf("解析响应失败：%v（响应内容：%s）", err, string(respBodyBytes))
+		}
+	}
+	return resp.Header, nil
+}
+
+// getAllPages 逐页获取分页接口的全部数据（path 中不需要包含 page/limit 参数）
+func getAllPages[T any](token *oauth2.Token, path string) ([]T, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		header, err := doRequest(token, http.MethodGet, fmt.Sprintf("%s%spage=%d&limit=100", path, sep, page), nil, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		// 根据分页响应头判断是否还有下一页
+		pageCount, _ := strconv.Atoi(header.Get("X-Pagination-Page-Count"))
+		if page >= pageCount || len(items) == 0 {
+			return all, nil
+		}
+	}
+}
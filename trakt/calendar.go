@@ -0,0 +1,42 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktCalendarEpisode 日历中即将播出的单集
+type TraktCalendarEpisode struct {
+	FirstAired time.Time    `json:"first_aired"`
+	Episode    TraktEpisode `json:"episode"`
+	Show       TraktShow    `json:"show"`
+}
+
+// TraktCalendarMovie 日历中即将上映的电影
+type TraktCalendarMovie struct {
+	Released string     `json:"released"` // 上映日期（YYYY-MM-DD）
+	Movie    TraktMovie `json:"movie"`
+}
+
+// GetMyShowsCalendar 获取自 start 起 days 天内用户所追剧集的播出日历
+func GetMyShowsCalendar(token *oauth2.Token, start time.Time, days int) ([]TraktCalendarEpisode, error) {
+	path := fmt.Sprintf("/calendars/my/shows/%s/%d?extended=full", start.Format("2006-01-02"), days)
+	var items []TraktCalendarEpisode
+	if _, err := doRequest(token, http.MethodGet, path, nil, &items); err != nil {
+		return nil, fmt.Errorf("获取剧集日历失败：%v", err)
+	}
+	return items, nil
+}
+
+// GetMyMoviesCalendar 获取自 start 起 days 天内观看清单与收藏中电影的上映日历
+func GetMyMoviesCalendar(token *oauth2.Token, start time.Time, days int) ([]TraktCalendarMovie, error) {
+	path := fmt.Sprintf("/calendars/my/movies/%s/%d?extended=full", start.Format("2006-01-02"), days)
+	var items []TraktCalendarMovie
+	if _, err := doRequest(token, http.MethodGet, path, nil, &items); err != nil {
+		return nil, fmt.Errorf("获取电影日历失败：%v", err)
+	}
+	return items, nil
+}
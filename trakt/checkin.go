@@ -0,0 +1,93 @@
+package trakt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// CheckinRequest 签到请求体（定位方式同 ScrobbleRequest）
+type CheckinRequest struct {
+	Movie      *SyncItem        `json:"movie,omitempty"`
+	Show       *SyncItem        `json:"show,omitempty"`
+	Episode    *ScrobbleEpisode `json:"episode,omitempty"`
+	Message    string           `json:"message,omitempty"`
+	AppVersion string           `json:"app_version,omitempty"`
+	AppDate    string           `json:"app_date,omitempty"`
+}
+
+// CheckinResult 签到响应
+type CheckinResult struct {
+	ID        int64         `json:"id"`
+	WatchedAt time.Time     `json:"watched_at"`
+	Movie     *TraktMovie   `json:"movie,omitempty"`
+	Show      *TraktShow    `json:"show,omitempty"`
+	Episode   *TraktEpisode `json:"episode,omitempty"`
+}
+
+// CheckinConflictError 已有进行中的签到（Trakt 返回 409）
+type CheckinConflictError struct {
+	ExpiresAt time.Time
+}
+
+func (e *CheckinConflictError) Error() string {
+	return fmt.Sprintf("已有进行中的签到，将于 %s 过期", e.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+}
+
+// TraktWatching 正在观看的条目（/users/me/watching）
+type TraktWatching struct {
+	ExpiresAt time.Time     `json:"expires_at"`
+	StartedAt time.Time     `json:"started_at"`
+	Action    string        `json:"action"` // checkin 或 scrobble
+	Type      string        `json:"type"`
+	Movie     *TraktMovie   `json:"movie,omitempty"`
+	Show      *TraktShow    `json:"show,omitempty"`
+	Episode   *TraktEpisode `json:"episode,omitempty"`
+}
+
+// Checkin 签到正在观看的电影或单集（已有签到时返回 *CheckinConflictError）
+func Checkin(token *oauth2.Token, req CheckinRequest) (*CheckinResult, error) {
+	if req.AppVersion == "" {
+		req.AppVersion = AppVersion
+		req.AppDate = AppDate
+	}
+
+	var result CheckinResult
+	_, err := doRequest(token, http.MethodPost, "/checkin", req, &result)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		var conflict struct {
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		json.Unmarshal(apiErr.Body, &conflict)
+		return nil, &CheckinConflictError{ExpiresAt: conflict.ExpiresAt}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("签到失败：%v", err)
+	}
+	return &result, nil
+}
+
+// DeleteCheckin 取消当前进行中的签到
+func DeleteCheckin(token *oauth2.Token) error {
+	if _, err := doRequest(token, http.MethodDelete, "/checkin", nil, nil); err != nil {
+		return fmt.Errorf("取消签到失败：%v", err)
+	}
+	return nil
+}
+
+// GetWatching 获取用户当前正在观看的条目（未在观看时返回nil）
+func GetWatching(token *oauth2.Token) (*TraktWatching, error) {
+	var watching TraktWatching
+	if _, err := doRequest(token, http.MethodGet, "/users/me/watching", nil, &watching); err != nil {
+		return nil, fmt.Errorf("获取正在观看失败：%v", err)
+	}
+	if watching.Type == "" {
+		return nil, nil
+	}
+	return &watching, nil
+}
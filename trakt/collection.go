@@ -0,0 +1,79 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// MediaMetadata 收藏条目的媒体信息
+type MediaMetadata struct {
+	CollectedAt   string `json:"collected_at,omitempty"`   // 收藏时间（RFC3339 或 released）
+	MediaType     string `json:"media_type,omitempty"`     // digital、bluray、hddvd、dvd、vcd、vhs、betamax、laserdisc
+	Resolution    string `json:"resolution,omitempty"`     // uhd_4k、hd_1080p、hd_1080i、hd_720p、sd_480p、sd_480i、sd_576p、sd_576i
+	HDR           string `json:"hdr,omitempty"`            // dolby_vision、hdr10、hdr10_plus、hlg
+	Audio         string `json:"audio,omitempty"`          // dolby_truehd、dolby_atmos、dts_x、dts_ma、aac、ac3 等
+	AudioChannels string `json:"audio_channels,omitempty"` // 1.0、2.0、5.1、7.1 等
+	ThreeD        bool   `json:"3d,omitempty"`
+}
+
+// TraktCollectedMovie 收藏中的电影
+type TraktCollectedMovie struct {
+	CollectedAt time.Time     `json:"collected_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Movie       TraktMovie    `json:"movie"`
+	Metadata    MediaMetadata `json:"metadata"`
+}
+
+// TraktCollectedShow 收藏中的剧集（含已收藏的各季单集）
+type TraktCollectedShow struct {
+	LastCollectedAt time.Time `json:"last_collected_at"`
+	LastUpdatedAt   time.Time `json:"last_updated_at"`
+	Show            TraktShow `json:"show"`
+	Seasons         []struct {
+		Number   int `json:"number"`
+		Episodes []struct {
+			Number      int           `json:"number"`
+			CollectedAt time.Time     `json:"collected_at"`
+			Metadata    MediaMetadata `json:"metadata"`
+		} `json:"episodes"`
+	} `json:"seasons"`
+}
+
+// GetCollectedMovies 获取收藏中的全部电影（含媒体信息）
+func GetCollectedMovies(token *oauth2.Token) ([]TraktCollectedMovie, error) {
+	var movies []TraktCollectedMovie
+	if _, err := doRequest(token, http.MethodGet, "/sync/collection/movies?extended=metadata", nil, &movies); err != nil {
+		return nil, fmt.Errorf("获取电影收藏失败：%v", err)
+	}
+	return movies, nil
+}
+
+// GetCollectedShows 获取收藏中的全部剧集（含各单集媒体信息）
+func GetCollectedShows(token *oauth2.Token) ([]TraktCollectedShow, error) {
+	var shows []TraktCollectedShow
+	if _, err := doRequest(token, http.MethodGet, "/sync/collection/shows?extended=metadata", nil, &shows); err != nil {
+		return nil, fmt.Errorf("获取剧集收藏失败：%v", err)
+	}
+	return shows, nil
+}
+
+// AddToCollection 添加条目到收藏（媒体信息通过 SyncItems.SetMetadata 设置）
+func AddToCollection(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/collection", items, &result); err != nil {
+		return nil, fmt.Errorf("添加到收藏失败：%v", err)
+	}
+	return &result, nil
+}
+
+// RemoveFromCollection 从收藏中移除条目
+func RemoveFromCollection(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/collection/remove", items, &result); err != nil {
+		return nil, fmt.Errorf("从收藏移除失败：%v", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,128 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// DiscoverFeeds 支持的发现榜单（boxoffice 仅适用于电影）
+var DiscoverFeeds = []string{"trending", "popular", "anticipated", "watched", "played", "collected", "boxoffice"}
+
+// DiscoverOptions 发现榜单的查询选项（过滤条件均为逗号分隔或范围格式，为空时不过滤）
+type DiscoverOptions struct {
+	Period         string // 统计周期（watched/played/collected）：daily、weekly、monthly、yearly、all
+	Genres         string // 类型 slug，如 action,drama
+	Years          string // 年份或范围，如 2020 或 2010-2019
+	Countries      string // 国家代码，如 us,cn
+	Languages      string // 语言代码，如 en,zh
+	Runtimes       string // 时长范围（分钟），如 30-90
+	Certifications string // 分级，如 pg-13,tv-ma
+	Limit          int
+}
+
+// DiscoverItem 榜单条目（不同榜单只填充对应的统计字段）
+type DiscoverItem struct {
+	Movie          *TraktMovie `json:"movie,omitempty"`
+	Show           *TraktShow  `json:"show,omitempty"`
+	Watchers       int         `json:"watchers,omitempty"`        // trending：正在观看人数
+	ListCount      int         `json:"list_count,omitempty"`      // anticipated：被加入列表次数
+	WatcherCount   int         `json:"watcher_count,omitempty"`   // watched/played/collected：观看人数
+	PlayCount      int         `json:"play_count,omitempty"`      // watched/played/collected：播放次数
+	CollectedCount int         `json:"collected_count,omitempty"` // watched/played/collected：收藏人数
+	Revenue        int64       `json:"revenue,omitempty"`         // boxoffice：票房（美元）
+}
+
+// TraktID 榜单条目的 Trakt ID
+func (d *DiscoverItem) TraktID() int {
+	if d.Movie != nil {
+		return d.Movie.IDs.Trakt
+	}
+	if d.Show != nil {
+		return d.Show.IDs.Trakt
+	}
+	return 0
+}
+
+// Discover 获取电影或剧集的发现榜单（mediaType：movies、shows；feed 见 DiscoverFeeds）
+func Discover(token *oauth2.Token, mediaType, feed string, opts DiscoverOptions) ([]DiscoverItem, error) {
+	path := fmt.Sprintf("/%s/%s", mediaType, feed)
+	switch feed {
+	case "watched", "played", "collected":
+		if opts.Period != "" {
+			path += "/" + opts.Period
+		}
+	case "boxoffice":
+		if mediaType != "movies" {
+			return nil, fmt.Errorf("票房榜仅支持电影")
+		}
+	}
+
+	params := url.Values{}
+	params.Set("extended", "full")
+	for key, value := range map[string]string{
+		"genres":         opts.Genres,
+		"years":          opts.Years,
+		"countries":      opts.Countries,
+		"languages":      opts.Languages,
+		"runtimes":       opts.Runtimes,
+		"certifications": opts.Certifications,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprint(opts.Limit))
+	}
+	path += "?" + params.Encode()
+
+	var items []DiscoverItem
+	var err error
+	if feed == "popular" {
+		// popular 榜单直接返回电影/剧集对象
+		items, err = getBareItems(token, mediaType, path)
+	} else {
+		_, err = doRequest(token, http.MethodGet, path, nil, &items)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("获取%s榜单失败：%v", feed, err)
+	}
+	return items, nil
+}
+
+// getBareItems 获取直接返回电影/剧集对象的接口数据，并包装为统一的榜单条目
+func getBareItems(token *oauth2.Token, mediaType, path string) ([]DiscoverItem, error) {
+	var items []DiscoverItem
+	if mediaType == "movies" {
+		var movies []TraktMovie
+		if _, err := doRequest(token, http.MethodGet, path, nil, &movies); err != nil {
+			return nil, err
+		}
+		for i := range movies {
+			items = append(items, DiscoverItem{Movie: &movies[i]})
+		}
+		return items, nil
+	}
+
+	var shows []TraktShow
+	if _, err := doRequest(token, http.MethodGet, path, nil, &shows); err != nil {
+		return nil, err
+	}
+	for i := range shows {
+		items = append(items, DiscoverItem{Show: &shows[i]})
+	}
+	return items, nil
+}
+
+// GetRelated 获取与指定电影或剧集相关的条目（mediaType：movies、shows；id 为 Trakt ID 或 slug）
+func GetRelated(token *oauth2.Token, mediaType, id string, limit int) ([]DiscoverItem, error) {
+	path := fmt.Sprintf("/%s/%s/related?extended=full&limit=%d", mediaType, url.PathEscape(id), limit)
+	items, err := getBareItems(token, mediaType, path)
+	if err != nil {
+		return nil, fmt.Errorf("获取相关条目失败：%v", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,104 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// GetHistoryRange 获取指定时间范围内的观看记录（itemType：movies、shows、seasons、episodes，为空时返回全部；时间为零值时不限制）
+func GetHistoryRange(token *oauth2.Token, itemType string, start, end time.Time) ([]TraktWatchHistoryItem, error) {
+	path := "/sync/history"
+	if itemType != "" {
+		path += "/" + itemType
+	}
+	path += "?extended=full"
+	if !start.IsZero() {
+		path += "&start_at=" + start.UTC().Format(time.RFC3339)
+	}
+	if !end.IsZero() {
+		path += "&end_at=" + end.UTC().Format(time.RFC3339)
+	}
+	items, err := getAllPages[TraktWatchHistoryItem](token, path)
+	if err != nil {
+		return nil, fmt.Errorf("获取观看记录失败：%v", err)
+	}
+	return items, nil
+}
+
+// GetHistoryPage 获取一页观看记录（按观看时间倒序，page 从1开始），同时返回总页数
+func GetHistoryPage(token *oauth2.Token, page, limit int) ([]TraktWatchHistoryItem, int, error) {
+	path := fmt.Sprintf("/sync/history?extended=full&page=%d&limit=%d", page, limit)
+	var items []TraktWatchHistoryItem
+	header, err := doRequest(token, http.MethodGet, path, nil, &items)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取观看记录失败：%v", err)
+	}
+	pages, _ := strconv.Atoi(header.Get("X-Pagination-Page-Count"))
+	return items, pages, nil
+}
+
+// ShowInfo 将观看记录中的剧集信息转换为 TraktShow（电影记录返回 nil）
+func (h *TraktWatchHistoryItem) ShowInfo() *TraktShow {
+	s := h.Show
+	if s == nil {
+		return nil
+	}
+	show := &TraktShow{
+		Title:         s.Title,
+		Year:          s.Year,
+		IDs:           TraktIDs{Trakt: s.IDs.Trakt, Slug: s.IDs.Slug, IMDB: s.IDs.IMDB, TMDb: s.IDs.TMDb, TVDB: s.IDs.TVDB},
+		Overview:      s.Overview,
+		Runtime:       s.Runtime,
+		Certification: s.Certification,
+		Network:       s.Network,
+		Country:       s.Country,
+		Language:      s.Language,
+		Genres:        s.Genres,
+		Status:        s.Status,
+		Rating:        s.Rating,
+		Votes:         s.Votes,
+		AiredEpisodes: s.AiredEpisodes,
+	}
+	if t, err := time.Parse(time.RFC3339, s.FirstAired); err == nil {
+		show.FirstAired = &t
+	}
+	return show
+}
+
+// AddToHistory 将条目标记为已观看（可通过 SyncItem.WatchedAt 指定观看时间）
+func AddToHistory(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/history", items, &result); err != nil {
+		return nil, fmt.Errorf("添加观看记录失败：%v", err)
+	}
+	return &result, nil
+}
+
+// RemoveFromHistory 删除观看记录（按条目删除其全部播放，或通过 SyncItems.IDs 删除指定的播放记录）
+func RemoveFromHistory(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/history/remove", items, &result); err != nil {
+		return nil, fmt.Errorf("删除观看记录失败：%v", err)
+	}
+	return &result, nil
+}
+
+// TraktWatchedMovie 已观看电影（/sync/watched/movies）
+type TraktWatchedMovie struct {
+	Plays         int        `json:"plays"`
+	LastWatchedAt time.Time  `json:"last_watched_at"`
+	Movie         TraktMovie `json:"movie"`
+}
+
+// GetWatchedMovies 获取用户所有已观看的电影
+func GetWatchedMovies(token *oauth2.Token) ([]TraktWatchedMovie, error) {
+	var movies []TraktWatchedMovie
+	if _, err := doRequest(token, http.MethodGet, "/sync/watched/movies?extended=full", nil, &movies); err != nil {
+		return nil, fmt.Errorf("获取已观看电影失败：%v", err)
+	}
+	return movies, nil
+}
@@ -0,0 +1,122 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktList 个人列表
+type TraktList struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Privacy        string    `json:"privacy"` // private、link、friends、public
+	DisplayNumbers bool      `json:"display_numbers"`
+	AllowComments  bool      `json:"allow_comments"`
+	SortBy         string    `json:"sort_by"`  // rank、added、title、released、runtime、popularity、percentage、votes 等
+	SortHow        string    `json:"sort_how"` // asc、desc
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	ItemCount      int       `json:"item_count"`
+	CommentCount   int       `json:"comment_count"`
+	Likes          int       `json:"likes"`
+	IDs            TraktIDs  `json:"ids"`
+	User           *struct {
+		Username string `json:"username"`
+		IDs      struct {
+			Slug string `json:"slug"`
+		} `json:"ids"`
+	} `json:"user,omitempty"`
+}
+
+// TraktLikedList 点赞的列表
+type TraktLikedList struct {
+	LikedAt time.Time `json:"liked_at"`
+	List    TraktList `json:"list"`
+}
+
+// ListSettings 创建/更新列表时的设置（更新时只提交非nil字段）
+type ListSettings struct {
+	Name           *string `json:"name,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Privacy        *string `json:"privacy,omitempty"`
+	DisplayNumbers *bool   `json:"display_numbers,omitempty"`
+	AllowComments  *bool   `json:"allow_comments,omitempty"`
+	SortBy         *string `json:"sort_by,omitempty"`
+	SortHow        *string `json:"sort_how,omitempty"`
+}
+
+// GetLists 获取用户的个人列表（user 为 me 时表示当前用户，其他用户仅返回其公开列表）
+func GetLists(token *oauth2.Token, user string) ([]TraktList, error) {
+	var lists []TraktList
+	if _, err := doRequest(token, http.MethodGet, "/users/"+url.PathEscape(user)+"/lists", nil, &lists); err != nil {
+		return nil, fmt.Errorf("获取列表失败：%v", err)
+	}
+	return lists, nil
+}
+
+// GetLikedLists 获取当前用户点赞的列表
+func GetLikedLists(token *oauth2.Token) ([]TraktLikedList, error) {
+	lists, err := getAllPages[TraktLikedList](token, "/users/me/likes/lists")
+	if err != nil {
+		return nil, fmt.Errorf("获取点赞的列表失败：%v", err)
+	}
+	return lists, nil
+}
+
+// GetListItems 获取列表中的条目（listID 为列表的 Trakt ID 或 slug）
+func GetListItems(token *oauth2.Token, user, listID string) ([]TraktListItem, error) {
+	path := fmt.Sprintf("/users/%s/lists/%s/items?extended=full", url.PathEscape(user), url.PathEscape(listID))
+	var items []TraktListItem
+	if _, err := doRequest(token, http.MethodGet, path, nil, &items); err != nil {
+		return nil, fmt.Errorf("获取列表条目失败：%v", err)
+	}
+	return items, nil
+}
+
+// CreateList 创建个人列表
+func CreateList(token *oauth2.Token, settings ListSettings) (*TraktList, error) {
+	var list TraktList
+	if _, err := doRequest(token, http.MethodPost, "/users/me/lists", settings, &list); err != nil {
+		return nil, fmt.Errorf("创建列表失败：%v", err)
+	}
+	return &list, nil
+}
+
+// UpdateList 更新列表名称、描述、隐私与排序设置
+func UpdateList(token *oauth2.Token, listID string, settings ListSettings) (*TraktList, error) {
+	var list TraktList
+	if _, err := doRequest(token, http.MethodPut, "/users/me/lists/"+url.PathEscape(listID), settings, &list); err != nil {
+		return nil, fmt.Errorf("更新列表失败：%v", err)
+	}
+	return &list, nil
+}
+
+// DeleteList 删除个人列表
+func DeleteList(token *oauth2.Token, listID string) error {
+	if _, err := doRequest(token, http.MethodDelete, "/users/me/lists/"+url.PathEscape(listID), nil, nil); err != nil {
+		return fmt.Errorf("删除列表失败：%v", err)
+	}
+	return nil
+}
+
+// AddListItems 向列表添加条目
+func AddListItems(token *oauth2.Token, listID string, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/users/me/lists/"+url.PathEscape(listID)+"/items", items, &result); err != nil {
+		return nil, fmt.Errorf("添加列表条目失败：%v", err)
+	}
+	return &result, nil
+}
+
+// RemoveListItems 从列表移除条目
+func RemoveListItems(token *oauth2.Token, listID string, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/users/me/lists/"+url.PathEscape(listID)+"/items/remove", items, &result); err != nil {
+		return nil, fmt.Errorf("移除列表条目失败：%v", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,209 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktWatchedShow 已观看剧集（/sync/watched/shows）
+type TraktWatchedShow struct {
+	Plays         int       `json:"plays"`
+	LastWatchedAt time.Time `json:"last_watched_at"`
+	Show          TraktShow `json:"show"`
+	Seasons       []struct {
+		Number   int `json:"number"`
+		Episodes []struct {
+			Number        int       `json:"number"`
+			Plays         int       `json:"plays"`
+			LastWatchedAt time.Time `json:"last_watched_at"`
+		} `json:"episodes"`
+	} `json:"seasons"`
+}
+
+// WatchedEpisodes 统计已观看的正片集数（不含第0季特别篇）
+func (w *TraktWatchedShow) WatchedEpisodes() int {
+	count := 0
+	for _, season := range w.Seasons {
+		if season.Number > 0 {
+			count += len(season.Episodes)
+		}
+	}
+	return count
+}
+
+// TraktShowProgress 剧集观看进度（/shows/{id}/progress/watched）
+type TraktShowProgress struct {
+	Aired         int        `json:"aired"`
+	Completed     int        `json:"completed"`
+	LastWatchedAt *time.Time `json:"last_watched_at"`
+	ResetAt       *time.Time `json:"reset_at"`
+	Seasons       []struct {
+		Number    int    `json:"number"`
+		Title     string `json:"title"`
+		Aired     int    `json:"aired"`
+		Completed int    `json:"completed"`
+		Episodes  []struct {
+			Number        int        `json:"number"`
+			Completed     bool       `json:"completed"`
+			LastWatchedAt *time.Time `json:"last_watched_at"`
+		} `json:"episodes"`
+	} `json:"seasons"`
+	HiddenSeasons []TraktSeason `json:"hidden_seasons"`
+	NextEpisode   *TraktEpisode `json:"next_episode"`
+	LastEpisode   *TraktEpisode `json:"last_episode"`
+}
+
+// TraktHiddenItem 被隐藏的条目（/users/hidden/{section}）
+type TraktHiddenItem struct {
+	HiddenAt time.Time    `json:"hidden_at"`
+	Type     string       `json:"type"`
+	Movie    *TraktMovie  `json:"movie,omitempty"`
+	Show     *TraktShow   `json:"show,omitempty"`
+	Season   *TraktSeason `json:"season,omitempty"`
+}
+
+// GetWatchedShows 获取用户所有已观看的剧集（含每季已看集数）
+func GetWatchedShows(token *oauth2.Token) ([]TraktWatchedShow, error) {
+	var shows []TraktWatchedShow
+	if _, err := doRequest(token, http.MethodGet, "/sync/watched/shows?extended=full", nil, &shows); err != nil {
+		return nil, fmt.Errorf("获取已观看剧集失败：%v", err)
+	}
+	return shows, nil
+}
+
+// GetShowProgress 获取单部剧集的观看进度（showID 可为 Trakt ID 或 slug）
+func GetShowProgress(token *oauth2.Token, showID string) (*TraktShowProgress, error) {
+	path := fmt.Sprintf("/shows/%s/progress/watched?hidden=false&specials=false&count_specials=false&extended=full", showID)
+	var progress TraktShowProgress
+	if _, err := doRequest(token, http.MethodGet, path, nil, &progress); err != nil {
+		return nil, fmt.Errorf("获取剧集进度失败：%v", err)
+	}
+	return &progress, nil
+}
+
+// GetHiddenItems 获取指定分区中被隐藏的条目（section：progress_watched、dropped、calendar 等；itemType 为空时返回全部类型）
+func GetHiddenItems(token *oauth2.Token, section, itemType string) ([]TraktHiddenItem, error) {
+	path := "/users/hidden/" + section
+	if itemType != "" {
+		path += "?type=" + itemType
+	}
+	items, err := getAllPages[TraktHiddenItem](token, path)
+	if err != nil {
+		return nil, fmt.Errorf("获取隐藏条目失败：%v", err)
+	}
+	return items, nil
+}
+
+// UpNextOptions 追剧进度查询选项
+type UpNextOptions struct {
+	SortBy         string // 排序方式：last-watched（默认，最近观看在前）或 air-date（下一集播出越早越靠前）
+	IncludeHidden  bool   // 是否包含在进度中被隐藏的剧集
+	IncludeDropped bool   // 是否包含已弃剧的剧集
+}
+
+// UpNextItem 追剧进度条目
+type UpNextItem struct {
+	Show          TraktShow
+	Progress      TraktShowProgress
+	LastWatchedAt time.Time
+	Hidden        bool // 在进度中被隐藏
+	Dropped       bool // 已弃剧
+}
+
+// Percent 已看完的百分比
+func (u *UpNextItem) Percent() float64 {
+	if u.Progress.Aired == 0 {
+		return 0
+	}
+	return float64(u.Progress.Completed) * 100 / float64(u.Progress.Aired)
+}
+
+// RemainingMinutes 剩余已播出未观看集数的预计总时长（分钟）
+func (u *UpNextItem) RemainingMinutes() int {
+	runtime := u.Show.Runtime
+	if u.Progress.NextEpisode != nil && u.Progress.NextEpisode.Runtime > 0 {
+		runtime = u.Progress.NextEpisode.Runtime
+	}
+	return (u.Progress.Aired - u.Progress.Completed) * runtime
+}
+
+// GetUpNext 获取所有未看完剧集的进度与下一集信息
+func GetUpNext(token *oauth2.Token, opts UpNextOptions) ([]UpNextItem, error) {
+	shows, err := GetWatchedShows(token)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden, err := hiddenShowIDs(token, "progress_watched")
+	if err != nil {
+		return nil, err
+	}
+	dropped, err := hiddenShowIDs(token, "dropped")
+	if err != nil {
+		return nil, err
+	}
+
+	var items []UpNextItem
+	for _, watched := range shows {
+		show := watched.Show
+		// 已看完所有已播出集数的剧集无需再查询进度
+		if show.AiredEpisodes > 0 && watched.WatchedEpisodes() >= show.AiredEpisodes {
+			continue
+		}
+		isHidden, isDropped := hidden[show.IDs.Trakt], dropped[show.IDs.Trakt]
+		if (isHidden && !opts.IncludeHidden) || (isDropped && !opts.IncludeDropped) {
+			continue
+		}
+
+		progress, err := GetShowProgress(token, fmt.Sprint(show.IDs.Trakt))
+		if err != nil {
+			return nil, fmt.Errorf("%s：%v", show.Title, err)
+		}
+		if progress.NextEpisode == nil {
+			continue
+		}
+		items = append(items, UpNextItem{
+			Show:          show,
+			Progress:      *progress,
+			LastWatchedAt: watched.LastWatchedAt,
+			Hidden:        isHidden,
+			Dropped:       isDropped,
+		})
+	}
+
+	sortUpNext(items, opts.SortBy)
+	return items, nil
+}
+
+// sortUpNext 按指定方式排序追剧进度
+func sortUpNext(items []UpNextItem, sortBy string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if sortBy == "air-date" {
+			a, b := items[i].Progress.NextEpisode.FirstAired, items[j].Progress.NextEpisode.FirstAired
+			if a == nil || b == nil {
+				return b == nil && a != nil
+			}
+			return a.Before(*b)
+		}
+		return items[i].LastWatchedAt.After(items[j].LastWatchedAt)
+	})
+}
+
+// hiddenShowIDs 获取指定隐藏分区中剧集的 Trakt ID 集合
+func hiddenShowIDs(token *oauth2.Token, section string) (map[int]bool, error) {
+	items, err := GetHiddenItems(token, section, "show")
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int]bool, len(items))
+	for _, item := range items {
+		if item.Show != nil {
+			ids[item.Show.IDs.Trakt] = true
+		}
+	}
+	return ids, nil
+}
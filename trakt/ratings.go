@@ -0,0 +1,127 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktRating 用户评分
+type TraktRating struct {
+	RatedAt time.Time     `json:"rated_at"`
+	Rating  int           `json:"rating"`
+	Type    string        `json:"type"`
+	Movie   *TraktMovie   `json:"movie,omitempty"`
+	Show    *TraktShow    `json:"show,omitempty"`
+	Season  *TraktSeason  `json:"season,omitempty"`
+	Episode *TraktEpisode `json:"episode,omitempty"`
+}
+
+// CommunityRating 社区评分与票数
+func (r *TraktRating) CommunityRating() (float64, int) {
+	switch r.Type {
+	case "movie":
+		if r.Movie != nil {
+			return r.Movie.Rating, r.Movie.Votes
+		}
+	case "show":
+		if r.Show != nil {
+			return r.Show.Rating, r.Show.Votes
+		}
+	case "season":
+		if r.Season != nil {
+			return r.Season.Rating, r.Season.Votes
+		}
+	case "episode":
+		if r.Episode != nil {
+			return r.Episode.Rating, r.Episode.Votes
+		}
+	}
+	return 0, 0
+}
+
+// TraktID 被评分条目的 Trakt ID
+func (r *TraktRating) TraktID() int {
+	switch r.Type {
+	case "movie":
+		if r.Movie != nil {
+			return r.Movie.IDs.Trakt
+		}
+	case "show":
+		if r.Show != nil {
+			return r.Show.IDs.Trakt
+		}
+	case "season":
+		if r.Season != nil {
+			return r.Season.IDs.Trakt
+		}
+	case "episode":
+		if r.Episode != nil {
+			return r.Episode.IDs.Trakt
+		}
+	}
+	return 0
+}
+
+// RatingIndex 按类型与 Trakt ID 索引的用户评分
+type RatingIndex map[string]int
+
+// NewRatingIndex 根据评分列表构建索引
+func NewRatingIndex(ratings []TraktRating) RatingIndex {
+	index := make(RatingIndex, len(ratings))
+	for i := range ratings {
+		index[ratingKey(ratings[i].Type, ratings[i].TraktID())] = ratings[i].Rating
+	}
+	return index
+}
+
+// Get 获取指定条目的用户评分（itemType：movie、show、season、episode；未评分时返回0）
+func (idx RatingIndex) Get(itemType string, traktID int) int {
+	return idx[ratingKey(itemType, traktID)]
+}
+
+// Set 更新指定条目的用户评分（rating 为0时删除）
+func (idx RatingIndex) Set(itemType string, traktID, rating int) {
+	if rating == 0 {
+		delete(idx, ratingKey(itemType, traktID))
+		return
+	}
+	idx[ratingKey(itemType, traktID)] = rating
+}
+
+// ratingKey 评分索引的键
+func ratingKey(itemType string, traktID int) string {
+	return fmt.Sprintf("%s:%d", itemType, traktID)
+}
+
+// GetRatings 获取用户评分（itemType：movies、shows、seasons、episodes，为空时返回全部）
+func GetRatings(token *oauth2.Token, itemType string) ([]TraktRating, error) {
+	if itemType == "" {
+		itemType = "all"
+	}
+	var ratings []TraktRating
+	if _, err := doRequest(token, http.MethodGet, "/sync/ratings/"+itemType+"?extended=full", nil, &ratings); err != nil {
+		return nil, fmt.Errorf("获取评分失败：%v", err)
+	}
+	return ratings, nil
+}
+
+// AddRatings 为条目评分（评分通过 SyncItems.SetRating 设置）
+func AddRatings(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/ratings", items, &result); err != nil {
+		return nil, fmt.Errorf("评分失败：%v", err)
+	}
+	return &result, nil
+}
+
+// RemoveRatings 删除条目的评分
+func RemoveRatings(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/ratings/remove", items, &result); err != nil {
+		return nil, fmt.Errorf("删除评分失败：%v", err)
+	}
+	return &result, nil
+}
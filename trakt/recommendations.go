@@ -0,0 +1,43 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// RecommendationOptions 个性化推荐的查询选项
+type RecommendationOptions struct {
+	IgnoreCollected   bool // 排除已收藏的条目
+	IgnoreWatchlisted bool // 排除已在观看清单中的条目
+	Limit             int
+}
+
+// GetRecommendations 获取 Trakt 个性化推荐（mediaType：movies、shows；结果复用榜单条目结构）
+func GetRecommendations(token *oauth2.Token, mediaType string, opts RecommendationOptions) ([]DiscoverItem, error) {
+	params := url.Values{}
+	params.Set("extended", "full")
+	params.Set("ignore_collected", fmt.Sprint(opts.IgnoreCollected))
+	params.Set("ignore_watchlisted", fmt.Sprint(opts.IgnoreWatchlisted))
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprint(opts.Limit))
+	}
+	path := fmt.Sprintf("/recommendations/%s?%s", mediaType, params.Encode())
+
+	items, err := getBareItems(token, mediaType, path)
+	if err != nil {
+		return nil, fmt.Errorf("获取推荐失败：%v", err)
+	}
+	return items, nil
+}
+
+// HideRecommendation 隐藏一条推荐（id 为 Trakt ID、slug 或 IMDb ID）
+func HideRecommendation(token *oauth2.Token, mediaType, id string) error {
+	path := fmt.Sprintf("/recommendations/%s/%s", mediaType, url.PathEscape(id))
+	if _, err := doRequest(token, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("隐藏推荐失败：%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// 应用版本信息（随 scrobble/checkin 请求上报）
+var (
+	AppVersion = "1.0"
+	AppDate    = "2026-10-19"
+)
+
+// ScrobbleRequest scrobble 请求体（电影通过 Movie 定位；单集通过 Episode 的ID定位，或 Show + Episode 的季号/集号定位）
+type ScrobbleRequest struct {
+	Movie      *SyncItem        `json:"movie,omitempty"`
+	Show       *SyncItem        `json:"show,omitempty"`
+	Episode    *ScrobbleEpisode `json:"episode,omitempty"`
+	Progress   float64          `json:"progress"` // 播放进度百分比（0-100）
+	AppVersion string           `json:"app_version,omitempty"`
+	AppDate    string           `json:"app_date,omitempty"`
+}
+
+// ScrobbleEpisode scrobble 请求中的单集
+type ScrobbleEpisode struct {
+	IDs       *TraktIDs `json:"ids,omitempty"`
+	Season    int       `json:"season,omitempty"`
+	Number    int       `json:"number,omitempty"`
+	NumberAbs int       `json:"number_abs,omitempty"`
+}
+
+// ScrobbleResult scrobble 响应
+type ScrobbleResult struct {
+	ID       int64         `json:"id"`
+	Action   string        `json:"action"` // start、pause、scrobble
+	Progress float64       `json:"progress"`
+	Movie    *TraktMovie   `json:"movie,omitempty"`
+	Show     *TraktShow    `json:"show,omitempty"`
+	Episode  *TraktEpisode `json:"episode,omitempty"`
+}
+
+// ScrobbleStart 开始播放（或从暂停恢复）
+func ScrobbleStart(token *oauth2.Token, req ScrobbleRequest) (*ScrobbleResult, error) {
+	return scrobble(token, "start", req)
+}
+
+// ScrobblePause 暂停播放
+func ScrobblePause(token *oauth2.Token, req ScrobbleRequest) (*ScrobbleResult, error) {
+	return scrobble(token, "pause", req)
+}
+
+// ScrobbleStop 停止播放（进度达到80%及以上时 Trakt 记为已观看，否则视为暂停）
+func ScrobbleStop(token *oauth2.Token, req ScrobbleRequest) (*ScrobbleResult, error) {
+	return scrobble(token, "stop", req)
+}
+
+// scrobble 发送 scrobble 请求（自动补充应用版本信息）
+func scrobble(token *oauth2.Token, action string, req ScrobbleRequest) (*ScrobbleResult, error) {
+	if req.AppVersion == "" {
+		req.AppVersion = AppVersion
+		req.AppDate = AppDate
+	}
+	var result ScrobbleResult
+	if _, err := doRequest(token, http.MethodPost, "/scrobble/"+action, req, &result); err != nil {
+		return nil, fmt.Errorf("scrobble %s 失败：%v", action, err)
+	}
+	return &result, nil
+}
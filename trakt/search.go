@@ -0,0 +1,137 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktSearchResult 搜索结果
+type TraktSearchResult struct {
+	Type    string        `json:"type"`
+	Score   float64       `json:"score"`
+	Movie   *TraktMovie   `json:"movie,omitempty"`
+	Show    *TraktShow    `json:"show,omitempty"`
+	Episode *TraktEpisode `json:"episode,omitempty"`
+	Person  *TraktPerson  `json:"person,omitempty"`
+}
+
+// TraktPerson 人物信息
+type TraktPerson struct {
+	Name     string   `json:"name"`
+	IDs      TraktIDs `json:"ids"`
+	Birthday string   `json:"birthday,omitempty"`
+}
+
+// SearchOptions 搜索选项
+type SearchOptions struct {
+	Years  string   // 年份或年份范围（如 2019 或 2010-2019）
+	Fields []string // 搜索字段（如 title、overview、aliases、original_title、name 等，为空时使用默认字段）
+	Limit  int      // 返回结果数（为0时使用接口默认值）
+}
+
+// Search 按关键词搜索（types 为 movie、show、episode、person 等类型）
+func Search(token *oauth2.Token, types []string, query string, opts SearchOptions) ([]TraktSearchResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("extended", "full")
+	if opts.Years != "" {
+		params.Set("years", opts.Years)
+	}
+	if len(opts.Fields) > 0 {
+		params.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", fmt.Sprint(opts.Limit))
+	}
+	path := fmt.Sprintf("/search/%s?%s", strings.Join(types, ","), params.Encode())
+	var results []TraktSearchResult
+	if _, err := doRequest(token, http.MethodGet, path, nil, &results); err != nil {
+		return nil, fmt.Errorf("搜索失败：%v", err)
+	}
+	return results, nil
+}
+
+// LookupID 按ID查找条目（idType：trakt、imdb、tmdb、tvdb、slug；itemType 为空时不限类型）
+func LookupID(token *oauth2.Token, idType, id, itemType string) ([]TraktSearchResult, error) {
+	if idType == "slug" {
+		return lookupSlug(token, id, itemType)
+	}
+	path := fmt.Sprintf("/search/%s/%s?extended=full", idType, url.PathEscape(id))
+	if itemType != "" {
+		path += "&type=" + itemType
+	}
+	var results []TraktSearchResult
+	if _, err := doRequest(token, http.MethodGet, path, nil, &results); err != nil {
+		return nil, fmt.Errorf("按ID查找失败：%v", err)
+	}
+	return results, nil
+}
+
+// lookupSlug 按 Trakt slug 查找电影或剧集（slug 不支持 /search 接口，需直接查询条目）
+func lookupSlug(token *oauth2.Token, slug, itemType string) ([]TraktSearchResult, error) {
+	var results []TraktSearchResult
+	if itemType == "" || itemType == "movie" {
+		var movie TraktMovie
+		_, err := doRequest(token, http.MethodGet, "/movies/"+url.PathEscape(slug)+"?extended=full", nil, &movie)
+		if err == nil {
+			results = append(results, TraktSearchResult{Type: "movie", Movie: &movie})
+		} else if !isNotFound(err) {
+			return nil, fmt.Errorf("按slug查找失败：%v", err)
+		}
+	}
+	if itemType == "" || itemType == "show" {
+		var show TraktShow
+		_, err := doRequest(token, http.MethodGet, "/shows/"+url.PathEscape(slug)+"?extended=full", nil, &show)
+		if err == nil {
+			results = append(results, TraktSearchResult{Type: "show", Show: &show})
+		} else if !isNotFound(err) {
+			return nil, fmt.Errorf("按slug查找失败：%v", err)
+		}
+	}
+	return results, nil
+}
+
+// Title 搜索结果的标题
+func (r *TraktSearchResult) Title() string {
+	switch {
+	case r.Movie != nil:
+		return r.Movie.Title
+	case r.Show != nil:
+		return r.Show.Title
+	case r.Episode != nil:
+		return r.Episode.Title
+	case r.Person != nil:
+		return r.Person.Name
+	}
+	return ""
+}
+
+// Year 搜索结果的年份（单集返回所属剧集的年份）
+func (r *TraktSearchResult) Year() int {
+	switch {
+	case r.Movie != nil:
+		return r.Movie.Year
+	case r.Show != nil:
+		return r.Show.Year
+	}
+	return 0
+}
+
+// IDs 搜索结果的ID（单集返回单集自身的ID）
+func (r *TraktSearchResult) IDs() TraktIDs {
+	switch {
+	case r.Movie != nil:
+		return r.Movie.IDs
+	case r.Episode != nil:
+		return r.Episode.IDs
+	case r.Show != nil:
+		return r.Show.IDs
+	case r.Person != nil:
+		return r.Person.IDs
+	}
+	return TraktIDs{}
+}
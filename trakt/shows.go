@@ -0,0 +1,31 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// GetSeasons 获取剧集的所有季及其单集（showID 可为 Trakt ID 或 slug）
+func GetSeasons(token *oauth2.Token, showID string) ([]TraktSeason, error) {
+	path := fmt.Sprintf("/shows/%s/seasons?extended=full,episodes", showID)
+	var seasons []TraktSeason
+	if _, err := doRequest(token, http.MethodGet, path, nil, &seasons); err != nil {
+		return nil, fmt.Errorf("获取剧集分季信息失败：%v", err)
+	}
+	return seasons, nil
+}
+
+// GetEpisode 获取单集信息（单集不存在时返回 nil）
+func GetEpisode(token *oauth2.Token, showID string, season, number int) (*TraktEpisode, error) {
+	path := fmt.Sprintf("/shows/%s/seasons/%d/episodes/%d?extended=full", showID, season, number)
+	var episode TraktEpisode
+	if _, err := doRequest(token, http.MethodGet, path, nil, &episode); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("获取单集信息失败：%v", err)
+	}
+	return &episode, nil
+}
@@ -0,0 +1,51 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// TraktUserStats 用户统计数据（/users/me/stats）
+type TraktUserStats struct {
+	Movies struct {
+		Plays     int `json:"plays"`
+		Watched   int `json:"watched"`
+		Minutes   int `json:"minutes"`
+		Collected int `json:"collected"`
+		Ratings   int `json:"ratings"`
+		Comments  int `json:"comments"`
+	} `json:"movies"`
+	Shows struct {
+		Watched   int `json:"watched"`
+		Collected int `json:"collected"`
+		Ratings   int `json:"ratings"`
+		Comments  int `json:"comments"`
+	} `json:"shows"`
+	Seasons struct {
+		Ratings  int `json:"ratings"`
+		Comments int `json:"comments"`
+	} `json:"seasons"`
+	Episodes struct {
+		Plays     int `json:"plays"`
+		Watched   int `json:"watched"`
+		Minutes   int `json:"minutes"`
+		Collected int `json:"collected"`
+		Ratings   int `json:"ratings"`
+		Comments  int `json:"comments"`
+	} `json:"episodes"`
+	Ratings struct {
+		Total        int            `json:"total"`
+		Distribution map[string]int `json:"distribution"` // 评分（"1"-"10"）→ 数量
+	} `json:"ratings"`
+}
+
+// GetUserStats 获取当前用户的统计数据
+func GetUserStats(token *oauth2.Token) (*TraktUserStats, error) {
+	var stats TraktUserStats
+	if _, err := doRequest(token, http.MethodGet, "/users/me/stats", nil, &stats); err != nil {
+		return nil, fmt.Errorf("获取统计数据失败：%v", err)
+	}
+	return &stats, nil
+}
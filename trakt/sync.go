@@ -0,0 +1,109 @@
+package trakt
+
+// SyncItems sync 系列写接口（watchlist、history、ratings、collection 等）的请求体
+type SyncItems struct {
+	Movies   []SyncItem `json:"movies,omitempty"`
+	Shows    []SyncItem `json:"shows,omitempty"`
+	Seasons  []SyncItem `json:"seasons,omitempty"`
+	Episodes []SyncItem `json:"episodes,omitempty"`
+	IDs      []int64    `json:"ids,omitempty"` // 观看记录ID（仅用于 /sync/history/remove）
+}
+
+// SyncItem sync 请求中的单个条目（按ID定位，剧集可通过 Seasons 指定季/集）
+type SyncItem struct {
+	Title     string       `json:"title,omitempty"` // 无ID时按标题与年份匹配（仅 scrobble/checkin 使用）
+	Year      int          `json:"year,omitempty"`
+	IDs       TraktIDs     `json:"ids"`
+	WatchedAt string       `json:"watched_at,omitempty"` // 观看时间（RFC3339 或 released）
+	Rating    int          `json:"rating,omitempty"`     // 评分（1-10，仅用于 /sync/ratings）
+	RatedAt   string       `json:"rated_at,omitempty"`   // 评分时间（RFC3339）
+	Seasons   []SyncSeason `json:"seasons,omitempty"`
+	MediaMetadata
+}
+
+// SyncSeason sync 请求中按季号定位的季（Episodes 为空时表示整季）
+type SyncSeason struct {
+	Number   int           `json:"number"`
+	Rating   int           `json:"rating,omitempty"`
+	Episodes []SyncEpisode `json:"episodes,omitempty"`
+}
+
+// SyncEpisode sync 请求中按集号定位的单集
+type SyncEpisode struct {
+	Number    int    `json:"number"`
+	WatchedAt string `json:"watched_at,omitempty"`
+	Rating    int    `json:"rating,omitempty"`
+	MediaMetadata
+}
+
+// SyncResult sync 系列写接口的响应（各字段按 movies/shows/seasons/episodes 计数）
+type SyncResult struct {
+	Added    map[string]int `json:"added,omitempty"`
+	Deleted  map[string]int `json:"deleted,omitempty"`
+	Existing map[string]int `json:"existing,omitempty"`
+	Updated  map[string]int `json:"updated,omitempty"`
+	NotFound SyncItems      `json:"not_found"`
+}
+
+// Add 合并另一组条目
+func (s *SyncItems) Add(other SyncItems) {
+	s.Movies = append(s.Movies, other.Movies...)
+	s.Shows = append(s.Shows, other.Shows...)
+	s.Seasons = append(s.Seasons, other.Seasons...)
+	s.Episodes = append(s.Episodes, other.Episodes...)
+	s.IDs = append(s.IDs, other.IDs...)
+}
+
+// Len 条目总数
+func (s *SyncItems) Len() int {
+	return len(s.Movies) + len(s.Shows) + len(s.Seasons) + len(s.Episodes) + len(s.IDs)
+}
+
+// SetWatchedAt 为所有条目设置观看时间
+func (s *SyncItems) SetWatchedAt(watchedAt string) {
+	for _, list := range [][]SyncItem{s.Movies, s.Shows, s.Seasons, s.Episodes} {
+		for i := range list {
+			list[i].WatchedAt = watchedAt
+		}
+	}
+}
+
+// SetRating 为所有条目设置评分（按季/集定位的剧集只为最内层的季或单集评分）
+func (s *SyncItems) SetRating(rating int) {
+	for _, list := range [][]SyncItem{s.Movies, s.Shows, s.Seasons, s.Episodes} {
+		for i := range list {
+			if len(list[i].Seasons) == 0 {
+				list[i].Rating = rating
+				continue
+			}
+			for j := range list[i].Seasons {
+				season := &list[i].Seasons[j]
+				if len(season.Episodes) == 0 {
+					season.Rating = rating
+					continue
+				}
+				for k := range season.Episodes {
+					season.Episodes[k].Rating = rating
+				}
+			}
+		}
+	}
+}
+
+// SetMetadata 为所有条目设置收藏时间与媒体信息（按季/集定位的剧集只为最内层的单集设置）
+func (s *SyncItems) SetMetadata(meta MediaMetadata) {
+	for _, list := range [][]SyncItem{s.Movies, s.Shows, s.Seasons, s.Episodes} {
+		for i := range list {
+			episodes := 0
+			for j := range list[i].Seasons {
+				for k := range list[i].Seasons[j].Episodes {
+					list[i].Seasons[j].Episodes[k].MediaMetadata = meta
+					episodes++
+				}
+			}
+			if episodes == 0 {
+				list[i].MediaMetadata = meta
+			}
+		}
+	}
+}
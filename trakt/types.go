@@ -0,0 +1,85 @@
+package trakt
+
+import "time"
+
+// TraktIDs 媒体条目的各平台ID
+type TraktIDs struct {
+	Trakt int    `json:"trakt,omitempty"`
+	Slug  string `json:"slug,omitempty"`
+	IMDB  string `json:"imdb,omitempty"`
+	TMDb  int    `json:"tmdb,omitempty"`
+	TVDB  int    `json:"tvdb,omitempty"`
+}
+
+// TraktShow 剧集信息（extended=full 时字段完整）
+type TraktShow struct {
+	Title         string     `json:"title"`
+	Year          int        `json:"year"`
+	IDs           TraktIDs   `json:"ids"`
+	Overview      string     `json:"overview,omitempty"`
+	FirstAired    *time.Time `json:"first_aired,omitempty"`
+	Runtime       int        `json:"runtime,omitempty"`
+	Certification string     `json:"certification,omitempty"`
+	Network       string     `json:"network,omitempty"`
+	Country       string     `json:"country,omitempty"`
+	Language      string     `json:"language,omitempty"`
+	Genres        []string   `json:"genres,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	Rating        float64    `json:"rating,omitempty"`
+	Votes         int        `json:"votes,omitempty"`
+	AiredEpisodes int        `json:"aired_episodes,omitempty"`
+}
+
+// TraktEpisode 单集信息（extended=full 时字段完整）
+type TraktEpisode struct {
+	Season     int        `json:"season"`
+	Number     int        `json:"number"`
+	Title      string     `json:"title"`
+	IDs        TraktIDs   `json:"ids"`
+	NumberAbs  int        `json:"number_abs,omitempty"`
+	Overview   string     `json:"overview,omitempty"`
+	FirstAired *time.Time `json:"first_aired,omitempty"`
+	Runtime    int        `json:"runtime,omitempty"`
+	Rating     float64    `json:"rating,omitempty"`
+	Votes      int        `json:"votes,omitempty"`
+}
+
+// TraktMovie 电影信息（extended=full 时字段完整）
+type TraktMovie struct {
+	Title         string   `json:"title"`
+	Year          int      `json:"year"`
+	IDs           TraktIDs `json:"ids"`
+	Tagline       string   `json:"tagline,omitempty"`
+	Overview      string   `json:"overview,omitempty"`
+	Released      string   `json:"released,omitempty"`
+	Runtime       int      `json:"runtime,omitempty"`
+	Certification string   `json:"certification,omitempty"`
+	Country       string   `json:"country,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	Genres        []string `json:"genres,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Rating        float64  `json:"rating,omitempty"`
+	Votes         int      `json:"votes,omitempty"`
+}
+
+// TraktSeason 季信息（extended=episodes 时包含单集列表）
+type TraktSeason struct {
+	Number   int            `json:"number"`
+	IDs      TraktIDs       `json:"ids"`
+	Rating   float64        `json:"rating,omitempty"`
+	Votes    int            `json:"votes,omitempty"`
+	Episodes []TraktEpisode `json:"episodes,omitempty"`
+}
+
+// TraktListItem 列表条目（观看清单、个人列表等通用）
+type TraktListItem struct {
+	Rank     int           `json:"rank"`
+	ID       int64         `json:"id"`
+	ListedAt time.Time     `json:"listed_at"`
+	Notes    string        `json:"notes,omitempty"`
+	Type     string        `json:"type"`
+	Movie    *TraktMovie   `json:"movie,omitempty"`
+	Show     *TraktShow    `json:"show,omitempty"`
+	Season   *TraktSeason  `json:"season,omitempty"`
+	Episode  *TraktEpisode `json:"episode,omitempty"`
+}
@@ -0,0 +1,58 @@
+package trakt
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// GetWatchlist 获取观看清单（itemType：movies、shows、seasons、episodes，为空时返回全部；sortBy：rank、added、title、released 等）
+func GetWatchlist(token *oauth2.Token, itemType, sortBy string) ([]TraktListItem, error) {
+	if itemType == "" {
+		itemType = "all"
+	}
+	if sortBy == "" {
+		sortBy = "rank"
+	}
+	path := fmt.Sprintf("/sync/watchlist/%s/%s/asc?extended=full", itemType, sortBy)
+	items, err := getAllPages[TraktListItem](token, path)
+	if err != nil {
+		return nil, fmt.Errorf("获取观看清单失败：%v", err)
+	}
+	return items, nil
+}
+
+// AddToWatchlist 添加条目到观看清单
+func AddToWatchlist(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/watchlist", items, &result); err != nil {
+		return nil, fmt.Errorf("添加到观看清单失败：%v", err)
+	}
+	return &result, nil
+}
+
+// RemoveFromWatchlist 从观看清单移除条目
+func RemoveFromWatchlist(token *oauth2.Token, items SyncItems) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := doRequest(token, http.MethodPost, "/sync/watchlist/remove", items, &result); err != nil {
+		return nil, fmt.Errorf("从观看清单移除失败：%v", err)
+	}
+	return &result, nil
+}
+
+// ReorderResult 重排接口的响应
+type ReorderResult struct {
+	Updated    int     `json:"updated"`
+	SkippedIDs []int64 `json:"skipped_ids"`
+}
+
+// ReorderWatchlist 按给定顺序重排观看清单（rank 为全部列表条目ID的新顺序）
+func ReorderWatchlist(token *oauth2.Token, rank []int64) (*ReorderResult, error) {
+	var result ReorderResult
+	body := map[string][]int64{"rank": rank}
+	if _, err := doRequest(token, http.MethodPost, "/sync/watchlist/reorder", body, &result); err != nil {
+		return nil, fmt.Errorf("重排观看清单失败：%v", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+
+	"traktshow/tui"
+)
+
+// runTUI 启动全屏终端界面（观看记录、追剧进度、日历、观看清单、统计）
+func runTUI(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("用法：tui")
+	}
+	return tui.Run(accessToken)
+}
@@ -0,0 +1,504 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+)
+
+// loadResult 异步加载的结果
+type loadResult struct {
+	tab   *tab
+	items []item
+	pages int
+	err   error
+}
+
+// actionResult 异步操作的结果（apply 在主循环中执行，用于更新界面数据）
+type actionResult struct {
+	message    string
+	err        error
+	apply      func()
+	background bool // 后台加载（不占用操作状态）
+}
+
+// prompt 底部输入框（评分输入、删除确认）
+type prompt struct {
+	label  string
+	input  string
+	submit func(input string)
+}
+
+// App 全屏终端界面
+type App struct {
+	token   *oauth2.Token
+	term    *terminal
+	tabs    []*tab
+	active  int
+	ratings trakt.RatingIndex
+	status  string
+	prompt  *prompt
+	busy    bool
+
+	loads   chan loadResult
+	actions chan actionResult
+}
+
+// Run 启动全屏界面，按 q 退出
+func Run(token *oauth2.Token) error {
+	term, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer term.Close()
+
+	a := &App{
+		token:   token,
+		term:    term,
+		tabs:    newTabs(),
+		loads:   make(chan loadResult),
+		actions: make(chan actionResult),
+		status:  "←/→ 切换标签  ↑/↓ 选择  r 评分  w 标记已看  x 删除播放  a 加入观看清单  d 移出观看清单  R 刷新  q 退出",
+	}
+	go func() {
+		ratings, err := trakt.GetRatings(token, "")
+		a.actions <- actionResult{err: err, background: true, apply: func() { a.ratings = trakt.NewRatingIndex(ratings) }}
+	}()
+
+	keys := make(chan rune)
+	go readKeys(keys)
+	resize := time.NewTicker(500 * time.Millisecond)
+	defer resize.Stop()
+
+	a.ensureLoaded(a.tabs[a.active])
+	rows, cols := term.size()
+	for {
+		a.render(rows, cols)
+
+		redraw := false
+		for !redraw {
+			select {
+			case key, ok := <-keys:
+				if !ok || !a.handleKey(key, rows) {
+					return nil
+				}
+				redraw = true
+			case r := <-a.loads:
+				a.finishLoad(r)
+				redraw = true
+			case r := <-a.actions:
+				a.finishAction(r)
+				redraw = true
+			case <-resize.C:
+				// 定期检查终端尺寸变化
+				if r, c := term.size(); r != rows || c != cols {
+					rows, cols = r, c
+					redraw = true
+				}
+			}
+		}
+	}
+}
+
+// finishAction 处理异步操作的结果
+func (a *App) finishAction(r actionResult) {
+	if !r.background {
+		a.busy = false
+	}
+	if r.err != nil {
+		a.status = "❌ " + r.err.Error()
+		return
+	}
+	if r.apply != nil {
+		r.apply()
+	}
+	if r.message != "" {
+		a.status = r.message
+	}
+}
+
+// current 当前标签页
+func (a *App) current() *tab {
+	return a.tabs[a.active]
+}
+
+// selected 当前选中的条目（没有时返回 nil）
+func (a *App) selected() *item {
+	t := a.current()
+	if t.cursor < 0 || t.cursor >= len(t.items) {
+		return nil
+	}
+	return &t.items[t.cursor]
+}
+
+// ensureLoaded 标签页尚未加载时开始异步加载第一页
+func (a *App) ensureLoaded(t *tab) {
+	if t.state == notLoaded {
+		a.startLoad(t, 1)
+	}
+}
+
+// startLoad 异步加载指定页
+func (a *App) startLoad(t *tab, page int) {
+	t.state = loading
+	go func() {
+		items, pages, err := t.load(a.token, page)
+		a.loads <- loadResult{tab: t, items: items, pages: pages, err: err}
+	}()
+	t.page = page
+}
+
+// finishLoad 合并加载结果（第一页替换，其余页追加）
+func (a *App) finishLoad(r loadResult) {
+	t := r.tab
+	t.state, t.err = loaded, r.err
+	if r.err != nil {
+		return
+	}
+	if t.page == 1 {
+		t.items = r.items
+		t.cursor = min(t.cursor, max(len(t.items)-1, 0))
+	} else {
+		t.items = append(t.items, r.items...)
+	}
+	t.pages = r.pages
+}
+
+// reload 重新加载标签页（保留光标位置）
+func (a *App) reload(t *tab) {
+	if t.state != loading {
+		a.startLoad(t, 1)
+	}
+}
+
+// handleKey 处理按键（返回 false 表示退出）
+func (a *App) handleKey(key rune, rows int) bool {
+	if a.prompt != nil {
+		a.handlePromptKey(key)
+		return true
+	}
+
+	t := a.current()
+	pageSize := max(rows-4, 1)
+	switch key {
+	case 'q', keyCtrlC:
+		return false
+	case keyLeft, 'h':
+		a.switchTab(a.active - 1)
+	case keyRight, 'l', keyTab:
+		a.switchTab(a.active + 1)
+	case '1', '2', '3', '4', '5':
+		a.switchTab(int(key - '1'))
+	case keyUp, 'k':
+		a.move(t, -1)
+	case keyDown, 'j':
+		a.move(t, 1)
+	case keyPageUp:
+		a.move(t, -pageSize)
+	case keyPageDown:
+		a.move(t, pageSize)
+	case keyHome, 'g':
+		a.move(t, -len(t.items))
+	case keyEnd, 'G':
+		a.move(t, len(t.items))
+	case 'R':
+		a.reload(t)
+	case 'r':
+		a.promptRating()
+	case 'w':
+		a.markWatched()
+	case 'x':
+		a.removePlay()
+	case 'a':
+		a.addToWatchlist()
+	case 'd':
+		a.removeFromWatchlist()
+	}
+	return true
+}
+
+// switchTab 切换标签页（循环）
+func (a *App) switchTab(i int) {
+	if i < 0 {
+		i = len(a.tabs) - 1
+	}
+	a.active = i % len(a.tabs)
+	a.ensureLoaded(a.current())
+}
+
+// move 移动光标；到达观看记录末尾时自动加载下一页
+func (a *App) move(t *tab, delta int) {
+	if len(t.items) == 0 {
+		return
+	}
+	t.cursor = min(max(t.cursor+delta, 0), len(t.items)-1)
+	if t.cursor == len(t.items)-1 && t.page < t.pages && t.state == loaded {
+		a.startLoad(t, t.page+1)
+	}
+}
+
+// handlePromptKey 处理输入框中的按键
+func (a *App) handlePromptKey(key rune) {
+	p := a.prompt
+	switch key {
+	case keyEscape, keyCtrlC:
+		a.prompt = nil
+		a.status = "已取消"
+	case keyEnter:
+		a.prompt = nil
+		p.submit(strings.TrimSpace(p.input))
+	case keyBackspace:
+		if n := len([]rune(p.input)); n > 0 {
+			p.input = string([]rune(p.input)[:n-1])
+		}
+	default:
+		if key > 0 {
+			p.input += string(key)
+		}
+	}
+}
+
+// run 异步执行操作（同一时间只执行一个）
+func (a *App) run(label string, action func() actionResult) {
+	if a.busy {
+		a.status = "请等待上一个操作完成"
+		return
+	}
+	a.busy = true
+	a.status = label + "…"
+	go func() { a.actions <- action() }()
+}
+
+// target 当前可操作的条目（没有时在状态栏提示）
+func (a *App) target() *item {
+	it := a.selected()
+	if it == nil || !it.hasMedia() {
+		a.status = "当前行没有可操作的条目"
+		return nil
+	}
+	return it
+}
+
+// promptRating 为选中条目评分（0 删除评分）
+func (a *App) promptRating() {
+	it := a.target()
+	if it == nil {
+		return
+	}
+	kind, id := it.kind()
+	a.prompt = &prompt{label: fmt.Sprintf("为「%s」评分（1-10，0 删除评分）：", it.title()), submit: func(input string) {
+		rating, err := strconv.Atoi(input)
+		if err != nil || rating < 0 || rating > 10 {
+			a.status = "评分必须是 0-10 的整数"
+			return
+		}
+		a.run("正在提交评分", func() actionResult {
+			var err error
+			if rating == 0 {
+				_, err = trakt.RemoveRatings(a.token, it.syncItems(false, nil))
+			} else {
+				_, err = trakt.AddRatings(a.token, it.syncItems(false, func(s *trakt.SyncItem) { s.Rating = rating }))
+			}
+			return actionResult{err: err, message: "✅ 评分已更新", apply: func() {
+				if a.ratings != nil {
+					a.ratings.Set(kind, id, rating)
+				}
+			}}
+		})
+	}}
+}
+
+// markWatched 将选中条目标记为已看（观看时间为当前时间；整部剧集需确认）
+func (a *App) markWatched() {
+	it := a.target()
+	if it == nil {
+		return
+	}
+	submit := func() {
+		a.run("正在标记为已看", func() actionResult {
+			now := time.Now().UTC().Format(time.RFC3339)
+			_, err := trakt.AddToHistory(a.token, it.syncItems(false, func(s *trakt.SyncItem) { s.WatchedAt = now }))
+			return actionResult{err: err, message: "✅ 已标记为已看：" + it.title(), apply: func() {
+				// 观看记录与追剧进度随之变化
+				a.reload(a.tabs[0])
+				if a.tabs[1].state == loaded {
+					a.reload(a.tabs[1])
+				}
+			}}
+		})
+	}
+	if kind, _ := it.kind(); kind == "show" || kind == "season" {
+		a.confirm(fmt.Sprintf("将「%s」的所有单集标记为已看？(y/n) ", it.title()), submit)
+		return
+	}
+	submit()
+}
+
+// removePlay 删除选中的播放记录（仅观看记录页）
+func (a *App) removePlay() {
+	it := a.target()
+	if it == nil || it.historyID == 0 {
+		a.status = "只能在观看记录页删除播放记录"
+		return
+	}
+	a.confirm(fmt.Sprintf("删除「%s」的这条播放记录？(y/n) ", it.title()), func() {
+		historyID := it.historyID
+		a.run("正在删除播放记录", func() actionResult {
+			_, err := trakt.RemoveFromHistory(a.token, trakt.SyncItems{IDs: []int64{historyID}})
+			return actionResult{err: err, message: "✅ 已删除播放记录", apply: func() {
+				t := a.tabs[0]
+				for i := range t.items {
+					if t.items[i].historyID == historyID {
+						t.items = append(t.items[:i], t.items[i+1:]...)
+						t.cursor = min(t.cursor, max(len(t.items)-1, 0))
+						break
+					}
+				}
+			}}
+		})
+	})
+}
+
+// addToWatchlist 将选中条目加入观看清单（单集以所属剧集为目标）
+func (a *App) addToWatchlist() {
+	it := a.target()
+	if it == nil {
+		return
+	}
+	a.run("正在加入观看清单", func() actionResult {
+		_, err := trakt.AddToWatchlist(a.token, it.syncItems(true, nil))
+		return actionResult{err: err, message: "✅ 已加入观看清单", apply: func() {
+			if t := a.tabs[3]; t.state == loaded {
+				a.reload(t)
+			}
+		}}
+	})
+}
+
+// removeFromWatchlist 将选中条目移出观看清单（仅观看清单页）
+func (a *App) removeFromWatchlist() {
+	it := a.target()
+	if it == nil || it.listID == 0 {
+		a.status = "只能在观看清单页移出条目"
+		return
+	}
+	a.confirm(fmt.Sprintf("将「%s」移出观看清单？(y/n) ", it.title()), func() {
+		a.run("正在移出观看清单", func() actionResult {
+			_, err := trakt.RemoveFromWatchlist(a.token, it.syncItems(false, nil))
+			return actionResult{err: err, message: "✅ 已移出观看清单", apply: func() { a.reload(a.tabs[3]) }}
+		})
+	})
+}
+
+// confirm 显示确认输入框，输入 y 时执行
+func (a *App) confirm(label string, yes func()) {
+	a.prompt = &prompt{label: label, submit: func(input string) {
+		if strings.EqualFold(input, "y") {
+			yes()
+		} else {
+			a.status = "已取消"
+		}
+	}}
+}
+
+// render 重绘整个界面：标签栏、列表、详情面板、状态栏
+func (a *App) render(rows, cols int) {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	moveTo := func(row, col int) { fmt.Fprintf(&b, "\x1b[%d;%dH", row, col) }
+
+	// 标签栏
+	moveTo(1, 1)
+	var bar strings.Builder
+	for i, t := range a.tabs {
+		label := fmt.Sprintf(" %d %s ", i+1, t.name)
+		if i == a.active {
+			bar.WriteString(reverseStyle + label + resetStyle)
+		} else {
+			bar.WriteString(label)
+		}
+		bar.WriteString(" ")
+	}
+	b.WriteString(bar.String())
+
+	// 宽终端左右分栏，窄终端只显示列表
+	listWidth, detailWidth := cols, 0
+	if cols >= 90 {
+		listWidth = cols * 11 / 20
+		detailWidth = cols - listWidth - 3
+	}
+	bodyRows := max(rows-3, 1)
+	t := a.current()
+	var body []string
+	switch {
+	case t.state == loading && len(t.items) == 0:
+		body = []string{"加载中…"}
+	case t.err != nil:
+		body = wrap("❌ 加载失败："+t.err.Error(), listWidth)
+	case len(t.items) == 0:
+		body = []string{"（没有内容）"}
+	}
+
+	if body != nil {
+		for i, line := range body {
+			if i >= bodyRows {
+				break
+			}
+			moveTo(i+3, 1)
+			b.WriteString(fit(line, listWidth))
+		}
+	} else {
+		if t.cursor < t.offset {
+			t.offset = t.cursor
+		}
+		if t.cursor >= t.offset+bodyRows {
+			t.offset = t.cursor - bodyRows + 1
+		}
+		for row := 0; row < bodyRows && t.offset+row < len(t.items); row++ {
+			i := t.offset + row
+			moveTo(row+3, 1)
+			line := fit(t.items[i].line, listWidth)
+			if i == t.cursor && t.items[i].hasMedia() {
+				line = reverseStyle + line + resetStyle
+			}
+			b.WriteString(line)
+		}
+
+		if detailWidth > 0 {
+			if it := a.selected(); it != nil {
+				for row, line := range it.detail(a.ratings, detailWidth) {
+					if row >= bodyRows {
+						break
+					}
+					moveTo(row+3, listWidth+3)
+					line = fit(line, detailWidth)
+					if row == 0 {
+						line = boldStyle + line + resetStyle
+					}
+					b.WriteString(line)
+				}
+			}
+		}
+	}
+
+	// 状态栏（分页信息 + 提示或输入框）
+	moveTo(rows, 1)
+	if a.prompt != nil {
+		b.WriteString(fit(a.prompt.label+a.prompt.input+"▏", cols))
+	} else {
+		position := ""
+		if len(t.items) > 0 {
+			position = fmt.Sprintf("[%d/%d] ", t.cursor+1, len(t.items))
+			if t.state == loading {
+				position = "[加载中] " + position
+			}
+		}
+		b.WriteString(dimStyle + fit(position+a.status, cols) + resetStyle)
+	}
+	os.Stdout.WriteString(strings.ReplaceAll(b.String(), "\n", "\r\n"))
+}
@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// item 列表中的一行（电影、剧集或单集；统计页等纯文本行不带条目）
+type item struct {
+	line      string
+	movie     *trakt.TraktMovie
+	show      *trakt.TraktShow
+	season    *trakt.TraktSeason
+	episode   *trakt.TraktEpisode
+	historyID int64     // 观看记录ID（仅观看记录页）
+	listID    int64     // 观看清单条目ID（仅观看清单页）
+	at        time.Time // 观看时间、播出时间等
+	extra     []string  // 详情中的附加信息
+}
+
+// hasMedia 是否对应具体条目（可执行操作）
+func (it *item) hasMedia() bool {
+	return it.movie != nil || it.show != nil || it.episode != nil
+}
+
+// kind 操作目标的类型与 Trakt ID（单集优先，其次为电影、季、剧集）
+func (it *item) kind() (string, int) {
+	switch {
+	case it.episode != nil:
+		return "episode", it.episode.IDs.Trakt
+	case it.movie != nil:
+		return "movie", it.movie.IDs.Trakt
+	case it.season != nil:
+		return "season", it.season.IDs.Trakt
+	case it.show != nil:
+		return "show", it.show.IDs.Trakt
+	}
+	return "", 0
+}
+
+// syncItems 构造 sync 接口的提交内容（preferShow 为 true 时单集以所属剧集为目标，如加入观看清单）
+func (it *item) syncItems(preferShow bool, fill func(*trakt.SyncItem)) trakt.SyncItems {
+	var items trakt.SyncItems
+	add := func(list *[]trakt.SyncItem, ids trakt.TraktIDs) {
+		entry := trakt.SyncItem{IDs: ids}
+		if fill != nil {
+			fill(&entry)
+		}
+		*list = append(*list, entry)
+	}
+	switch {
+	case it.movie != nil:
+		add(&items.Movies, it.movie.IDs)
+	case it.show != nil && (preferShow || it.episode == nil && it.season == nil):
+		add(&items.Shows, it.show.IDs)
+	case it.episode != nil:
+		add(&items.Episodes, it.episode.IDs)
+	case it.season != nil:
+		add(&items.Seasons, it.season.IDs)
+	}
+	return items
+}
+
+// title 条目标题
+func (it *item) title() string {
+	return utils.FormatMediaTitle(it.movie, it.show, it.season, it.episode)
+}
+
+// detail 详情面板的内容（第一行为标题）
+func (it *item) detail(ratings trakt.RatingIndex, width int) []string {
+	if !it.hasMedia() {
+		return nil
+	}
+	lines := []string{it.title(), ""}
+	field := func(label, value string) {
+		if value != "" && value != "0" {
+			lines = append(lines, wrap(label+"："+value, width)...)
+		}
+	}
+
+	var overview string
+	switch {
+	case it.movie != nil:
+		m := it.movie
+		field("类型", "电影")
+		field("年份", fmt.Sprint(m.Year))
+		field("上映", m.Released)
+		field("时长", formatRuntime(m.Runtime))
+		field("分类", strings.Join(m.Genres, ", "))
+		field("国家/语言", strings.Trim(m.Country+" / "+m.Language, " /"))
+		field("社区评分", formatCommunityRating(m.Rating, m.Votes))
+		field("IMDb", m.IDs.IMDB)
+		overview = m.Overview
+	case it.show != nil:
+		s := it.show
+		field("剧集", fmt.Sprintf("%s (%d)", s.Title, s.Year))
+		field("电视网", s.Network)
+		field("状态", s.Status)
+		field("分类", strings.Join(s.Genres, ", "))
+		field("国家/语言", strings.Trim(s.Country+" / "+s.Language, " /"))
+		if s.AiredEpisodes > 0 {
+			field("已播出", fmt.Sprintf("%d 集", s.AiredEpisodes))
+		}
+		field("社区评分", formatCommunityRating(s.Rating, s.Votes))
+		overview = s.Overview
+	}
+	if e := it.episode; e != nil {
+		lines = append(lines, "")
+		field("单集", utils.FormatEpisodeCode(e.Season, e.Number)+" "+e.Title)
+		if e.NumberAbs > 0 {
+			field("绝对集数", fmt.Sprint(e.NumberAbs))
+		}
+		if e.FirstAired != nil {
+			field("首播", e.FirstAired.Local().Format("2006-01-02 15:04"))
+		}
+		field("时长", formatRuntime(e.Runtime))
+		field("社区评分", formatCommunityRating(e.Rating, e.Votes))
+		if e.Overview != "" {
+			overview = e.Overview
+		}
+	}
+
+	if kind, id := it.kind(); ratings != nil {
+		if rating := ratings.Get(kind, id); rating > 0 {
+			field("我的评分", fmt.Sprintf("%d/10", rating))
+		}
+	}
+	if !it.at.IsZero() && it.historyID > 0 {
+		field("观看时间", it.at.Local().Format("2006-01-02 15:04"))
+	}
+	for _, line := range it.extra {
+		lines = append(lines, wrap(line, width)...)
+	}
+	if overview != "" {
+		lines = append(lines, "")
+		lines = append(lines, wrap(overview, width)...)
+	}
+	return lines
+}
+
+// formatRuntime 时长（为0时返回空）
+func formatRuntime(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	return utils.FormatMinutes(minutes)
+}
+
+// formatCommunityRating 社区评分（无投票时返回空）
+func formatCommunityRating(rating float64, votes int) string {
+	if votes == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f（%d 票）", rating, votes)
+}
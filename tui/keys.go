@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"os"
+	"unicode/utf8"
+)
+
+// 特殊按键（普通字符直接使用其 rune 值）
+const (
+	keyUp rune = -(iota + 1)
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyTab
+	keyCtrlC
+)
+
+// 转义序列对应的按键
+var escapeSequences = map[string]rune{
+	"\x1b[A": keyUp, "\x1b[B": keyDown, "\x1b[C": keyRight, "\x1b[D": keyLeft,
+	"\x1bOA": keyUp, "\x1bOB": keyDown, "\x1bOC": keyRight, "\x1bOD": keyLeft,
+	"\x1b[5~": keyPageUp, "\x1b[6~": keyPageDown,
+	"\x1b[H": keyHome, "\x1b[F": keyEnd, "\x1b[1~": keyHome, "\x1b[4~": keyEnd,
+}
+
+// readKeys 持续读取标准输入并解析为按键（原始模式下一次读取通常包含完整的转义序列）
+func readKeys(keys chan<- rune) {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseKeys 解析一次读取到的字节
+func parseKeys(data []byte) []rune {
+	var keys []rune
+	for len(data) > 0 {
+		if data[0] == 0x1b {
+			if len(data) == 1 {
+				return append(keys, keyEscape)
+			}
+			matched := false
+			for seq, key := range escapeSequences {
+				if len(data) >= len(seq) && string(data[:len(seq)]) == seq {
+					keys = append(keys, key)
+					data = data[len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// 未知序列整体忽略
+				return append(keys, keyEscape)
+			}
+			continue
+		}
+
+		switch data[0] {
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 0x7f, 0x08:
+			keys = append(keys, keyBackspace)
+		case '\t':
+			keys = append(keys, keyTab)
+		case 0x03:
+			keys = append(keys, keyCtrlC)
+		default:
+			r, size := utf8.DecodeRune(data)
+			keys = append(keys, r)
+			data = data[size:]
+			continue
+		}
+		data = data[1:]
+	}
+	return keys
+}
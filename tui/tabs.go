@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// 观看记录每页条数
+const historyPageSize = 100
+
+// 日历显示的天数
+const calendarDays = 30
+
+// 星期的中文名称
+var weekdayNames = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+// tab 标签页（数据异步加载，观看记录支持分页追加）
+type tab struct {
+	name   string
+	load   func(token *oauth2.Token, page int) ([]item, int, error) // 返回条目与总页数
+	items  []item
+	cursor int
+	offset int
+	page   int // 已加载的页数
+	pages  int // 总页数
+	state  loadState
+	err    error
+}
+
+// loadState 标签页的加载状态
+type loadState int
+
+const (
+	notLoaded loadState = iota
+	loading
+	loaded
+)
+
+// newTabs 创建所有标签页
+func newTabs() []*tab {
+	return []*tab{
+		{name: "观看记录", load: loadHistory},
+		{name: "追剧进度", load: loadUpNext},
+		{name: "日历", load: loadCalendar},
+		{name: "观看清单", load: loadWatchlist},
+		{name: "统计", load: loadStats},
+	}
+}
+
+// loadHistory 加载一页观看记录
+func loadHistory(token *oauth2.Token, page int) ([]item, int, error) {
+	history, pages, err := trakt.GetHistoryPage(token, page, historyPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	items := make([]item, 0, len(history))
+	for i := range history {
+		h := &history[i]
+		it := item{movie: h.Movie, show: h.ShowInfo(), episode: h.Episode, historyID: h.ID, at: h.WatchedAt}
+		it.line = fmt.Sprintf("%s  %s", h.WatchedAt.Local().Format("01-02 15:04"), it.title())
+		items = append(items, it)
+	}
+	return items, pages, nil
+}
+
+// loadUpNext 加载追剧进度（每部剧集的下一集）
+func loadUpNext(token *oauth2.Token, _ int) ([]item, int, error) {
+	upNext, err := trakt.GetUpNext(token, trakt.UpNextOptions{SortBy: "last-watched"})
+	if err != nil {
+		return nil, 0, err
+	}
+	items := make([]item, 0, len(upNext))
+	for i := range upNext {
+		u := &upNext[i]
+		next := u.Progress.NextEpisode
+		it := item{show: &u.Show, episode: next, at: u.LastWatchedAt}
+		it.line = fmt.Sprintf("%-4s %s", fmt.Sprintf("%.0f%%", u.Percent()), it.title())
+		it.extra = []string{
+			fmt.Sprintf("进度：%d/%d 集（%.0f%%）", u.Progress.Completed, u.Progress.Aired, u.Percent()),
+			"上次观看：" + u.LastWatchedAt.Local().Format("2006-01-02"),
+		}
+		if minutes := u.RemainingMinutes(); minutes > 0 {
+			it.extra = append(it.extra, "剩余时长：约 "+utils.FormatMinutes(minutes))
+		}
+		items = append(items, it)
+	}
+	return items, 1, nil
+}
+
+// loadCalendar 加载未来一段时间的剧集播出与电影上映日历
+func loadCalendar(token *oauth2.Token, _ int) ([]item, int, error) {
+	start := time.Now()
+	episodes, err := trakt.GetMyShowsCalendar(token, start, calendarDays)
+	if err != nil {
+		return nil, 0, err
+	}
+	movies, err := trakt.GetMyMoviesCalendar(token, start, calendarDays)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var items []item
+	for i := range episodes {
+		e := &episodes[i]
+		items = append(items, item{show: &e.Show, episode: &e.Episode, at: e.FirstAired.Local()})
+	}
+	for i := range movies {
+		m := &movies[i]
+		released, err := time.ParseInLocation("2006-01-02", m.Released, time.Local)
+		if err != nil {
+			continue
+		}
+		items = append(items, item{movie: &m.Movie, at: released})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].at.Before(items[j].at) })
+
+	for i := range items {
+		it := &items[i]
+		when := it.at.Format("01-02 ") + weekdayNames[it.at.Weekday()]
+		if it.episode != nil {
+			when += it.at.Format(" 15:04")
+		} else {
+			when += " 上映 "
+		}
+		it.line = fmt.Sprintf("%s  %s", when, it.title())
+	}
+	return items, 1, nil
+}
+
+// loadWatchlist 加载观看清单（按排序）
+func loadWatchlist(token *oauth2.Token, _ int) ([]item, int, error) {
+	list, err := trakt.GetWatchlist(token, "", "rank")
+	if err != nil {
+		return nil, 0, err
+	}
+	items := make([]item, 0, len(list))
+	for i := range list {
+		l := &list[i]
+		it := item{movie: l.Movie, show: l.Show, season: l.Season, episode: l.Episode, listID: l.ID, at: l.ListedAt}
+		it.line = fmt.Sprintf("%3d. %s", l.Rank, it.title())
+		it.extra = []string{"加入时间：" + l.ListedAt.Local().Format("2006-01-02")}
+		if l.Notes != "" {
+			it.extra = append(it.extra, "备注："+l.Notes)
+		}
+		items = append(items, it)
+	}
+	return items, 1, nil
+}
+
+// loadStats 加载账号统计（纯文本行，含评分分布柱状图）
+func loadStats(token *oauth2.Token, _ int) ([]item, int, error) {
+	stats, err := trakt.GetUserStats(token)
+	if err != nil {
+		return nil, 0, err
+	}
+	lines := []string{
+		"电影",
+		fmt.Sprintf("  已观看 %d 部，播放 %d 次，共 %s", stats.Movies.Watched, stats.Movies.Plays, utils.FormatMinutes(stats.Movies.Minutes)),
+		fmt.Sprintf("  收藏 %d 部，评分 %d 部", stats.Movies.Collected, stats.Movies.Ratings),
+		"",
+		"剧集",
+		fmt.Sprintf("  已观看 %d 部，%d 集，播放 %d 次，共 %s", stats.Shows.Watched, stats.Episodes.Watched, stats.Episodes.Plays, utils.FormatMinutes(stats.Episodes.Minutes)),
+		fmt.Sprintf("  收藏 %d 集，评分 %d 部剧集、%d 季、%d 集", stats.Episodes.Collected, stats.Shows.Ratings, stats.Seasons.Ratings, stats.Episodes.Ratings),
+		"",
+		fmt.Sprintf("总观看时长：%s", utils.FormatMinutes(stats.Movies.Minutes+stats.Episodes.Minutes)),
+		"",
+		fmt.Sprintf("评分分布（共 %d 条）", stats.Ratings.Total),
+	}
+	maxCount := 0
+	for _, n := range stats.Ratings.Distribution {
+		maxCount = max(maxCount, n)
+	}
+	for rating := 10; rating >= 1; rating-- {
+		n := stats.Ratings.Distribution[fmt.Sprint(rating)]
+		bar := 0
+		if maxCount > 0 {
+			bar = n * 30 / maxCount
+		}
+		lines = append(lines, fmt.Sprintf("  %2d  %-30s %d", rating, strings.Repeat("█", bar), n))
+	}
+
+	items := make([]item, len(lines))
+	for i, line := range lines {
+		items[i].line = line
+	}
+	return items, 1, nil
+}
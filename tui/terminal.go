@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ANSI 控制序列
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	clearScreen    = "\x1b[2J"
+	resetStyle     = "\x1b[0m"
+	reverseStyle   = "\x1b[7m"
+	boldStyle      = "\x1b[1m"
+	dimStyle       = "\x1b[2m"
+)
+
+// terminal 终端状态（进入原始模式前的 stty 设置，用于退出时恢复）
+type terminal struct {
+	saved string
+}
+
+// openTerminal 切换到原始模式与备用屏幕（通过 stty，仅支持类 Unix 终端）
+func openTerminal() (*terminal, error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("当前环境不是交互式终端：%v", err)
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("切换终端模式失败：%v", err)
+	}
+	fmt.Print(enterAltScreen + hideCursor)
+	return &terminal{saved: strings.TrimSpace(saved)}, nil
+}
+
+// Close 恢复终端设置
+func (t *terminal) Close() {
+	fmt.Print(resetStyle + showCursor + exitAltScreen)
+	stty(t.saved)
+}
+
+// size 终端的行数与列数（获取失败时返回 24x80）
+func (t *terminal) size() (rows, cols int) {
+	out, err := stty("size")
+	if err == nil {
+		if _, err := fmt.Sscan(out, &rows, &cols); err == nil && rows > 0 && cols > 0 {
+			return rows, cols
+		}
+	}
+	return 24, 80
+}
+
+// stty 以当前终端为标准输入执行 stty
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// runeWidth 字符的显示宽度（中日韩字符与全角符号占两列）
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.Is(unicode.Mn, r):
+		return 0
+	case r >= 0x1100 && r <= 0x115f, r >= 0x2e80 && r <= 0xa4cf, r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff, r >= 0xfe30 && r <= 0xfe4f, r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6, r >= 0x1f300 && r <= 0x1faff, r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	}
+	return 1
+}
+
+// displayWidth 字符串的显示宽度
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// fit 将字符串截断或补齐到指定显示宽度（截断时以 … 结尾）
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if w := displayWidth(s); w <= width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	b.WriteString("…")
+	return b.String() + strings.Repeat(" ", width-used-1)
+}
+
+// wrap 按显示宽度折行
+func wrap(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		var line strings.Builder
+		used := 0
+		for len(paragraph) > 0 {
+			r, size := utf8.DecodeRuneInString(paragraph)
+			paragraph = paragraph[size:]
+			w := runeWidth(r)
+			if used+w > width {
+				lines = append(lines, line.String())
+				line.Reset()
+				used = 0
+			}
+			line.WriteRune(r)
+			used += w
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
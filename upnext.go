@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runUpNext 查看所有追剧中的剧集进度与下一集
+func runUpNext(args []string) error {
+	fs := flag.NewFlagSet("up-next", flag.ExitOnError)
+	sortBy := fs.String("sort", "last-watched", "排序方式：last-watched（最近观看）或 air-date（下一集播出日期，最早的在前）")
+	includeHidden := fs.Bool("hidden", false, "包含在进度中被隐藏的剧集")
+	includeDropped := fs.Bool("dropped", false, "包含已弃剧的剧集")
+	fs.Parse(args)
+
+	if *sortBy != "last-watched" && *sortBy != "air-date" {
+		return fmt.Errorf("不支持的排序方式：%s", *sortBy)
+	}
+
+	items, err := trakt.GetUpNext(accessToken, trakt.UpNextOptions{
+		SortBy:         *sortBy,
+		IncludeHidden:  *includeHidden,
+		IncludeDropped: *includeDropped,
+	})
+	if err != nil {
+		return err
+	}
+	utils.PrintUpNext(items)
+	return nil
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// 本地缓存目录（用户主目录下的隐藏目录）
+const cacheDirName = ".trakt-cache"
+
+// cacheEntry 缓存文件内容（记录保存时间）
+type cacheEntry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// SaveCache 将数据以JSON格式保存到本地缓存
+func SaveCache(name string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("序列化缓存失败：%v", err)
+	}
+	entry, err := json.Marshal(cacheEntry{SavedAt: time.Now(), Data: raw})
+	if err != nil {
+		return fmt.Errorf("序列化缓存失败：%v", err)
+	}
+
+	dir := GetCacheDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("创建缓存目录失败：%v", err)
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), entry, 0600)
+}
+
+// LoadCache 从本地缓存读取数据，返回缓存的保存时间（缓存不存在时返回错误）
+func LoadCache(name string, data interface{}) (time.Time, error) {
+	content, err := os.ReadFile(filepath.Join(GetCacheDir(), name+".json"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("读取缓存失败：%v", err)
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return time.Time{}, fmt.Errorf("解析缓存失败：%v", err)
+	}
+	if err := json.Unmarshal(entry.Data, data); err != nil {
+		return time.Time{}, fmt.Errorf("解析缓存失败：%v", err)
+	}
+	return entry.SavedAt, nil
+}
+
+// DeleteCache 删除本地缓存（缓存不存在时不报错）
+func DeleteCache(name string) error {
+	err := os.Remove(filepath.Join(GetCacheDir(), name+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除缓存失败：%v", err)
+	}
+	return nil
+}
+
+// Cached 优先读取本地缓存，缓存不存在或 refresh 为 true 时调用 fetch 获取并写入缓存
+func Cached[T any](name string, refresh bool, fetch func() (T, error)) (T, error) {
+	var data T
+	if !refresh {
+		if _, err := LoadCache(name, &data); err == nil {
+			return data, nil
+		}
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return data, err
+	}
+	if err := SaveCache(name, data); err != nil {
+		log.Printf("⚠️  缓存保存失败：%v（不影响本次使用）", err)
+	}
+	return data, nil
+}
+
+// CachedWithin 缓存保存时间在 maxAge 以内时直接使用，否则调用 fetch 更新缓存（fetch 失败时退回到过期的缓存）
+func CachedWithin[T any](name string, maxAge time.Duration, fetch func() (T, error)) (T, error) {
+	var cached T
+	savedAt, cacheErr := LoadCache(name, &cached)
+	if cacheErr == nil && time.Since(savedAt) < maxAge {
+		return cached, nil
+	}
+
+	data, err := fetch()
+	if err != nil {
+		if cacheErr == nil {
+			log.Printf("⚠️  更新 %s 失败，使用 %s 的缓存：%v", name, savedAt.Format("01-02 15:04"), err)
+			return cached, nil
+		}
+		return data, err
+	}
+	if err := SaveCache(name, data); err != nil {
+		log.Printf("⚠️  缓存保存失败：%v（不影响本次使用）", err)
+	}
+	return data, nil
+}
+
+// GetCacheDir 获取本地缓存目录路径（与配置文件同在用户主目录）
+func GetCacheDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		log.Panicf("获取用户主目录失败：%v", err)
+	}
+	return filepath.Join(homeDir, cacheDirName)
+}
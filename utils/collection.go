@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// resolutionOrder 分辨率从高到低的排序（未知分辨率排在最后）
+var resolutionOrder = []string{"uhd_4k", "hd_1080p", "hd_1080i", "hd_720p", "sd_576p", "sd_576i", "sd_480p", "sd_480i", ""}
+
+// PrintCollection 格式化打印收藏中的电影与剧集
+func PrintCollection(movies []trakt.TraktCollectedMovie, shows []trakt.TraktCollectedShow) {
+	fmt.Printf("\n===== 电影收藏（%d 部） =====\n", len(movies))
+	for _, m := range movies {
+		fmt.Printf("%s  %s\n", FormatMediaTitle(&m.Movie, nil, nil, nil), FormatMediaMetadata(m.Metadata))
+	}
+
+	fmt.Printf("\n===== 剧集收藏（%d 部） =====\n", len(shows))
+	for _, s := range shows {
+		episodes := 0
+		for _, season := range s.Seasons {
+			episodes += len(season.Episodes)
+		}
+		fmt.Printf("%s  共 %d 季 %d 集\n", FormatMediaTitle(nil, &s.Show, nil, nil), len(s.Seasons), episodes)
+	}
+	fmt.Printf("=============================\n")
+}
+
+// PrintCollectionQualityReport 按分辨率分组打印收藏质量报告（含 HDR、音频统计）
+func PrintCollectionQualityReport(movies []trakt.TraktCollectedMovie, shows []trakt.TraktCollectedShow) {
+	type group struct {
+		movies, episodes int
+		hdr, audio       map[string]int
+	}
+	groups := make(map[string]*group)
+	add := func(meta trakt.MediaMetadata, isMovie bool) {
+		g := groups[meta.Resolution]
+		if g == nil {
+			g = &group{hdr: make(map[string]int), audio: make(map[string]int)}
+			groups[meta.Resolution] = g
+		}
+		if isMovie {
+			g.movies++
+		} else {
+			g.episodes++
+		}
+		if meta.HDR != "" {
+			g.hdr[meta.HDR]++
+		}
+		if meta.Audio != "" {
+			g.audio[strings.TrimSpace(meta.Audio+" "+meta.AudioChannels)]++
+		}
+	}
+
+	for _, m := range movies {
+		add(m.Metadata, true)
+	}
+	for _, s := range shows {
+		for _, season := range s.Seasons {
+			for _, e := range season.Episodes {
+				add(e.Metadata, false)
+			}
+		}
+	}
+
+	fmt.Printf("\n===== 收藏质量报告 =====\n")
+	for _, resolution := range resolutionOrder {
+		g := groups[resolution]
+		if g == nil {
+			continue
+		}
+		fmt.Printf("\n【%s】电影 %d 部，单集 %d 集\n", getResolutionName(resolution), g.movies, g.episodes)
+		if len(g.hdr) > 0 {
+			fmt.Printf("  HDR：%s\n", formatCounts(g.hdr))
+		}
+		if len(g.audio) > 0 {
+			fmt.Printf("  音频：%s\n", formatCounts(g.audio))
+		}
+	}
+	fmt.Printf("=============================\n")
+}
+
+// FormatMediaMetadata 格式化媒体信息（如「4K HDR10 dolby_atmos 7.1 bluray」）
+func FormatMediaMetadata(meta trakt.MediaMetadata) string {
+	var parts []string
+	if meta.Resolution != "" {
+		parts = append(parts, getResolutionName(meta.Resolution))
+	}
+	for _, p := range []string{meta.HDR, meta.Audio, meta.AudioChannels, meta.MediaType} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if meta.ThreeD {
+		parts = append(parts, "3D")
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatCounts 按数量从多到少格式化计数（如「hdr10 ×3, dolby_vision ×1」）
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s ×%d", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getResolutionName 获取分辨率的显示名称
+func getResolutionName(resolution string) string {
+	switch resolution {
+	case "uhd_4k":
+		return "4K"
+	case "hd_1080p", "hd_1080i", "hd_720p", "sd_576p", "sd_576i", "sd_480p", "sd_480i":
+		return resolution[strings.Index(resolution, "_")+1:]
+	case "":
+		return "未知分辨率"
+	default:
+		return resolution
+	}
+}
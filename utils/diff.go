@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/backup"
+	"traktshow/trakt"
+)
+
+// PrintDiff 格式化打印两份备份之间的差异
+func PrintDiff(diff *backup.Diff) {
+	fmt.Printf("\n===== 账号变化（%s → %s） =====\n", diff.OldCreatedAt, diff.NewCreatedAt)
+	if diff.Empty() {
+		fmt.Println("没有任何变化")
+		fmt.Printf("=============================\n")
+		return
+	}
+
+	printPlays("新增观看记录", "+", diff.AddedPlays)
+	printPlays("删除观看记录", "-", diff.RemovedPlays)
+
+	if len(diff.RatingChanges) > 0 {
+		fmt.Printf("\n【评分变化】%d 项\n", len(diff.RatingChanges))
+		for _, c := range diff.RatingChanges {
+			switch {
+			case c.Old == nil:
+				fmt.Printf("  + %s：%d\n", formatRatingTitle(c.New), c.New.Rating)
+			case c.New == nil:
+				fmt.Printf("  - %s：%d（已删除）\n", formatRatingTitle(c.Old), c.Old.Rating)
+			default:
+				fmt.Printf("  ~ %s：%d → %d\n", formatRatingTitle(c.New), c.Old.Rating, c.New.Rating)
+			}
+		}
+	}
+
+	printListItemChanges("观看清单新增", "+", diff.WatchlistAdded)
+	printListItemChanges("观看清单移除", "-", diff.WatchlistRemoved)
+
+	for _, c := range diff.ListChanges {
+		switch {
+		case c.Created:
+			fmt.Printf("\n【新建列表】%s（%d 项）\n", c.Name, len(c.ItemsAdded))
+		case c.Deleted:
+			fmt.Printf("\n【删除列表】%s（%d 项）\n", c.Name, len(c.ItemsRemoved))
+		default:
+			fmt.Printf("\n【列表变化】%s\n", c.Name)
+			if c.RenamedFrom != "" {
+				fmt.Printf("  重命名：%s → %s\n", c.RenamedFrom, c.Name)
+			}
+			for _, item := range c.ItemsAdded {
+				fmt.Printf("  + %s\n", FormatMediaTitle(item.Movie, item.Show, item.Season, item.Episode))
+			}
+			for _, item := range c.ItemsRemoved {
+				fmt.Printf("  - %s\n", FormatMediaTitle(item.Movie, item.Show, item.Season, item.Episode))
+			}
+		}
+	}
+	fmt.Printf("=============================\n")
+}
+
+// printPlays 打印观看记录变化
+func printPlays(title, sign string, plays []trakt.TraktWatchHistoryItem) {
+	if len(plays) == 0 {
+		return
+	}
+	fmt.Printf("\n【%s】%d 条\n", title, len(plays))
+	for i := range plays {
+		fmt.Printf("  %s %s  %s\n", sign, plays[i].WatchedAt.Local().Format("2006-01-02 15:04"), FormatHistoryTitle(&plays[i]))
+	}
+}
+
+// printListItemChanges 打印列表条目变化
+func printListItemChanges(title, sign string, items []trakt.TraktListItem) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Printf("\n【%s】%d 项\n", title, len(items))
+	for _, item := range items {
+		fmt.Printf("  %s %s\n", sign, FormatMediaTitle(item.Movie, item.Show, item.Season, item.Episode))
+	}
+}
+
+// formatRatingTitle 格式化评分条目的标题
+func formatRatingTitle(r *trakt.TraktRating) string {
+	return fmt.Sprintf("[%s] %s", getTypeChineseName(r.Type), FormatMediaTitle(r.Movie, r.Show, r.Season, r.Episode))
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// PrintDiscover 格式化打印发现榜单（watched 为已观看条目的 Trakt ID 集合）
+func PrintDiscover(feed string, items []trakt.DiscoverItem, watched map[int]bool) {
+	fmt.Printf("\n===== %s榜单（%d 项） =====\n", getFeedChineseName(feed), len(items))
+	for i, item := range items {
+		mark := ""
+		if watched[item.TraktID()] {
+			mark = " ✔ 已看过"
+		}
+		fmt.Printf("%2d. %s%s\n", i+1, FormatMediaTitle(item.Movie, item.Show, nil, nil), mark)
+
+		var details []string
+		if stat := formatDiscoverStat(item); stat != "" {
+			details = append(details, stat)
+		}
+		rating, genres := 0.0, []string(nil)
+		if item.Movie != nil {
+			rating, genres = item.Movie.Rating, item.Movie.Genres
+		} else if item.Show != nil {
+			rating, genres = item.Show.Rating, item.Show.Genres
+		}
+		if rating > 0 {
+			details = append(details, fmt.Sprintf("评分 %.1f", rating))
+		}
+		if len(genres) > 0 {
+			details = append(details, strings.Join(genres, "/"))
+		}
+		if len(details) > 0 {
+			fmt.Printf("    %s\n", strings.Join(details, "  "))
+		}
+	}
+	fmt.Printf("=============================\n")
+}
+
+// formatDiscoverStat 格式化榜单对应的统计数据
+func formatDiscoverStat(item trakt.DiscoverItem) string {
+	switch {
+	case item.Watchers > 0:
+		return fmt.Sprintf("%d 人正在看", item.Watchers)
+	case item.ListCount > 0:
+		return fmt.Sprintf("%d 人期待", item.ListCount)
+	case item.Revenue > 0:
+		return fmt.Sprintf("票房 $%d", item.Revenue)
+	case item.WatcherCount > 0:
+		return fmt.Sprintf("%d 人看过，播放 %d 次，%d 人收藏", item.WatcherCount, item.PlayCount, item.CollectedCount)
+	}
+	return ""
+}
+
+// getFeedChineseName 获取榜单的中文名称
+func getFeedChineseName(feed string) string {
+	switch feed {
+	case "trending":
+		return "趋势"
+	case "popular":
+		return "热门"
+	case "anticipated":
+		return "最受期待"
+	case "watched":
+		return "观看最多"
+	case "played":
+		return "播放最多"
+	case "collected":
+		return "收藏最多"
+	case "boxoffice":
+		return "票房"
+	case "recommendations":
+		return "个性化推荐"
+	default:
+		return feed
+	}
+}
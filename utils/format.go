@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// FormatEpisodeCode 格式化单集编码（如 S01E02）
+func FormatEpisodeCode(season, number int) string {
+	return fmt.Sprintf("S%02dE%02d", season, number)
+}
+
+// FormatMinutes 将分钟数格式化为「X小时Y分钟」
+func FormatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d分钟", minutes)
+	}
+	return fmt.Sprintf("%d小时%d分钟", minutes/60, minutes%60)
+}
+
+// FormatMediaTitle 格式化条目标题（电影/剧集带年份，季/单集带所属剧集与编码）
+func FormatMediaTitle(movie *trakt.TraktMovie, show *trakt.TraktShow, season *trakt.TraktSeason, episode *trakt.TraktEpisode) string {
+	switch {
+	case episode != nil && show != nil:
+		return fmt.Sprintf("%s %s %s", show.Title, FormatEpisodeCode(episode.Season, episode.Number), episode.Title)
+	case episode != nil:
+		return fmt.Sprintf("%s %s", FormatEpisodeCode(episode.Season, episode.Number), episode.Title)
+	case season != nil && show != nil:
+		return fmt.Sprintf("%s 第%d季", show.Title, season.Number)
+	case show != nil:
+		return fmt.Sprintf("%s (%d)", show.Title, show.Year)
+	case movie != nil:
+		return fmt.Sprintf("%s (%d)", movie.Title, movie.Year)
+	}
+	return "未知条目"
+}
+
+// PrintSyncResult 格式化打印 sync 写接口的处理结果
+func PrintSyncResult(result *trakt.SyncResult) {
+	printSyncCounts("新增", result.Added)
+	printSyncCounts("删除", result.Deleted)
+	printSyncCounts("已存在", result.Existing)
+	printSyncCounts("更新", result.Updated)
+	if n := result.NotFound.Len(); n > 0 {
+		fmt.Printf("⚠️  未找到 %d 个条目\n", n)
+	}
+}
+
+// printSyncCounts 打印各类型的计数（全为0时不打印）
+func printSyncCounts(label string, counts map[string]int) {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if total == 0 {
+		return
+	}
+	fmt.Printf("%s：电影 %d，剧集 %d，季 %d，单集 %d\n", label, counts["movies"], counts["shows"], counts["seasons"], counts["episodes"])
+}
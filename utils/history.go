@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// FormatHistoryTitle 格式化观看记录的标题
+func FormatHistoryTitle(item *trakt.TraktWatchHistoryItem) string {
+	if item.Movie != nil {
+		return FormatMediaTitle(item.Movie, nil, nil, nil)
+	}
+	if item.Show == nil {
+		return "未知条目"
+	}
+	show := &trakt.TraktShow{Title: item.Show.Title, Year: item.Show.Year}
+	return FormatMediaTitle(nil, show, nil, item.Episode)
+}
+
+// PrintHistoryBrief 逐行打印观看记录摘要（记录ID、观看时间、标题）
+func PrintHistoryBrief(history []trakt.TraktWatchHistoryItem) {
+	for _, item := range history {
+		fmt.Printf("  [%d] %s  %s\n", item.ID, item.WatchedAt.Local().Format("2006-01-02 15:04"), FormatHistoryTitle(&item))
+	}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/library"
+)
+
+// PrintScanReport 打印媒体目录与收藏的比较结果（remove 为 false 时仅列出收藏中本地不存在的条目数量）
+func PrintScanReport(added, missing []library.Entry, unidentified []string, remove bool) {
+	fmt.Printf("\n===== 待添加到收藏（%d 条） =====\n", len(added))
+	for _, e := range added {
+		fmt.Printf("➕ %s  %s\n", formatEntry(&e), FormatMediaMetadata(e.Metadata))
+	}
+
+	if remove {
+		fmt.Printf("\n===== 待从收藏移除（%d 条） =====\n", len(missing))
+		for _, e := range missing {
+			fmt.Printf("➖ %s\n", formatEntry(&e))
+		}
+	} else if len(missing) > 0 {
+		fmt.Printf("\n收藏中有 %d 条在本地目录中不存在（使用 -remove 移除）\n", len(missing))
+	}
+
+	if len(unidentified) > 0 {
+		fmt.Printf("\n===== 无法识别（%d 个文件） =====\n", len(unidentified))
+		for _, line := range unidentified {
+			fmt.Printf("❓ %s\n", line)
+		}
+	}
+	fmt.Printf("=============================\n")
+}
+
+// formatEntry 本地条目的标题（如「Show (2019) S01E02」）
+func formatEntry(e *library.Entry) string {
+	title := e.Title
+	if e.Year > 0 {
+		title = fmt.Sprintf("%s (%d)", title, e.Year)
+	}
+	if e.Kind == "episode" {
+		title += " " + FormatEpisodeCode(e.Season, e.Episode)
+	}
+	return title
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// PrintListItems 格式化打印列表条目（观看清单、个人列表等）
+func PrintListItems(title string, items []trakt.TraktListItem) {
+	fmt.Printf("\n===== %s（%d 项） =====\n", title, len(items))
+	for _, item := range items {
+		fmt.Printf("%3d. [%s] %s\n", item.Rank, getTypeChineseName(item.Type), FormatMediaTitle(item.Movie, item.Show, item.Season, item.Episode))
+		fmt.Printf("     条目ID：%d  添加时间：%s\n", item.ID, item.ListedAt.Local().Format("2006-01-02 15:04"))
+		if item.Notes != "" {
+			fmt.Printf("     备注：%s\n", item.Notes)
+		}
+	}
+	fmt.Printf("=============================\n")
+}
+
+// PrintLists 格式化打印个人列表
+func PrintLists(title string, lists []trakt.TraktList) {
+	fmt.Printf("\n===== %s（%d 个） =====\n", title, len(lists))
+	for _, list := range lists {
+		owner := ""
+		if list.User != nil {
+			owner = "  作者：" + list.User.Username
+		}
+		fmt.Printf("• %s（%d 项，%s）%s\n", list.Name, list.ItemCount, getPrivacyChineseName(list.Privacy), owner)
+		fmt.Printf("  ID：%d  slug：%s  排序：%s %s  点赞：%d\n", list.IDs.Trakt, list.IDs.Slug, list.SortBy, list.SortHow, list.Likes)
+		if list.Description != "" {
+			fmt.Printf("  描述：%s\n", list.Description)
+		}
+	}
+	fmt.Printf("=============================\n")
+}
+
+// getPrivacyChineseName 获取列表隐私设置的中文名称
+func getPrivacyChineseName(privacy string) string {
+	switch privacy {
+	case "private":
+		return "私密"
+	case "link":
+		return "凭链接访问"
+	case "friends":
+		return "仅好友"
+	case "public":
+		return "公开"
+	default:
+		return privacy
+	}
+}
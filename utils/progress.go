@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// PrintUpNext 格式化打印追剧进度与下一集
+func PrintUpNext(items []trakt.UpNextItem) {
+	fmt.Printf("\n===== 追剧进度（%d 部） =====\n", len(items))
+	for i, item := range items {
+		next := item.Progress.NextEpisode
+		fmt.Printf("\n【%d】%s (%d)%s\n", i+1, item.Show.Title, item.Show.Year, getHiddenMark(item))
+		fmt.Printf("进度：%d/%d 集（%.1f%%）\n", item.Progress.Completed, item.Progress.Aired, item.Percent())
+		fmt.Printf("下一集：%s %s\n", FormatEpisodeCode(next.Season, next.Number), next.Title)
+		if next.FirstAired != nil {
+			fmt.Printf("播出日期：%s\n", next.FirstAired.Local().Format("2006-01-02"))
+		}
+		fmt.Printf("剩余时长：%s\n", FormatMinutes(item.RemainingMinutes()))
+		fmt.Printf("最近观看：%s\n", item.LastWatchedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Printf("=============================\n")
+}
+
+// getHiddenMark 获取隐藏/弃剧标记
+func getHiddenMark(item trakt.UpNextItem) string {
+	switch {
+	case item.Dropped:
+		return " [已弃剧]"
+	case item.Hidden:
+		return " [已隐藏]"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"traktshow/trakt"
+)
+
+// PrintRatings 格式化打印用户评分列表
+func PrintRatings(ratings []trakt.TraktRating) {
+	fmt.Printf("\n===== 我的评分（%d 项） =====\n", len(ratings))
+	for _, r := range ratings {
+		community, votes := r.CommunityRating()
+		fmt.Printf("%2d/10  [%s] %s  （社区 %.2f，%d票，评分于 %s）\n",
+			r.Rating, getTypeChineseName(r.Type), FormatMediaTitle(r.Movie, r.Show, r.Season, r.Episode),
+			community, votes, r.RatedAt.Local().Format("2006-01-02"))
+	}
+	fmt.Printf("=============================\n")
+}
+
+// PrintRatingDistribution 打印评分分布直方图与平均分
+func PrintRatingDistribution(ratings []trakt.TraktRating) {
+	var counts [11]int
+	total, rated := 0, 0
+	for _, r := range ratings {
+		if r.Rating >= 1 && r.Rating <= 10 {
+			counts[r.Rating]++
+			total += r.Rating
+			rated++
+		}
+	}
+
+	maxCount := 0
+	for _, c := range counts {
+		maxCount = max(maxCount, c)
+	}
+
+	fmt.Printf("\n===== 评分分布（%d 项） =====\n", len(ratings))
+	for score := 10; score >= 1; score-- {
+		bar := 0
+		if maxCount > 0 {
+			bar = counts[score] * 40 / maxCount
+		}
+		fmt.Printf("%2d | %-40s %d\n", score, strings.Repeat("█", bar), counts[score])
+	}
+	if rated > 0 {
+		fmt.Printf("平均分：%.2f\n", float64(total)/float64(rated))
+	}
+	fmt.Printf("=============================\n")
+}
+
+// PrintRatingDeviation 打印我的评分与社区评分偏差最大的条目（limit<=0 时打印全部）
+func PrintRatingDeviation(ratings []trakt.TraktRating, limit int) {
+	type deviation struct {
+		rating *trakt.TraktRating
+		diff   float64
+	}
+	var list []deviation
+	for i := range ratings {
+		community, votes := ratings[i].CommunityRating()
+		if votes == 0 {
+			continue
+		}
+		list = append(list, deviation{rating: &ratings[i], diff: float64(ratings[i].Rating) - community})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return math.Abs(list[i].diff) > math.Abs(list[j].diff)
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	fmt.Printf("\n===== 我的评分 vs 社区评分 =====\n")
+	for _, d := range list {
+		r := d.rating
+		community, _ := r.CommunityRating()
+		fmt.Printf("%+5.2f  我 %2d / 社区 %.2f  [%s] %s\n", d.diff, r.Rating, community,
+			getTypeChineseName(r.Type), FormatMediaTitle(r.Movie, r.Show, r.Season, r.Episode))
+	}
+	fmt.Printf("=============================\n")
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// PrintScrobbleResult 打印 scrobble 结果
+func PrintScrobbleResult(result *trakt.ScrobbleResult) {
+	fmt.Printf("✅ %s：%s（进度 %.1f%%）\n", getScrobbleActionName(result.Action),
+		FormatMediaTitle(result.Movie, result.Show, nil, result.Episode), result.Progress)
+}
+
+// getScrobbleActionName 获取 scrobble 动作的中文名称
+func getScrobbleActionName(action string) string {
+	switch action {
+	case "start":
+		return "开始播放"
+	case "pause":
+		return "已暂停"
+	case "scrobble":
+		return "已记录观看"
+	default:
+		return action
+	}
+}
+
+// PrintCheckinResult 打印签到结果
+func PrintCheckinResult(result *trakt.CheckinResult) {
+	fmt.Printf("✅ 已签到：%s（%s）\n", FormatMediaTitle(result.Movie, result.Show, nil, result.Episode),
+		result.WatchedAt.Local().Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+
+	"traktshow/trakt"
+)
+
+// PrintSearchResults 格式化打印搜索结果（附带可直接用于其他命令的ID）
+func PrintSearchResults(results []trakt.TraktSearchResult) {
+	fmt.Printf("\n===== 搜索结果（%d 项） =====\n", len(results))
+	for i, r := range results {
+		var title string
+		if r.Person != nil {
+			title = r.Person.Name
+		} else {
+			title = FormatMediaTitle(r.Movie, r.Show, nil, r.Episode)
+		}
+		fmt.Printf("%2d. [%s] %s\n", i+1, getTypeChineseName(r.Type), title)
+
+		ids := r.IDs()
+		fmt.Printf("    trakt:%d  slug:%s", ids.Trakt, ids.Slug)
+		if ids.IMDB != "" {
+			fmt.Printf("  %s", ids.IMDB)
+		}
+		if ids.TMDb > 0 {
+			fmt.Printf("  tmdb:%d", ids.TMDb)
+		}
+		if ids.TVDB > 0 {
+			fmt.Printf("  tvdb:%d", ids.TVDB)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("=============================\n")
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"traktshow/recommender"
+)
+
+// PrintSuggestions 格式化打印本地推荐结果及推荐理由
+func PrintSuggestions(profile *recommender.Profile, recommendations []recommender.Recommendation) {
+	fmt.Printf("\n===== 口味画像（基于 %d 部作品） =====\n", profile.Titles)
+	var prefs []string
+	for _, f := range profile.TopFeatures(8) {
+		prefs = append(prefs, fmt.Sprintf("%s（%+.2f）", f.Describe(), f.Score))
+	}
+	fmt.Println(strings.Join(prefs, "、"))
+
+	fmt.Printf("\n===== 本地推荐（%d 项） =====\n", len(recommendations))
+	for i, rec := range recommendations {
+		fmt.Printf("%2d. %s  得分 %.2f\n", i+1, FormatMediaTitle(rec.Item.Movie, rec.Item.Show, nil, nil), rec.Score)
+
+		var reasons []string
+		for _, r := range rec.Reasons {
+			if r.Score <= 0 || len(reasons) == 3 {
+				break
+			}
+			reasons = append(reasons, fmt.Sprintf("%s +%.2f", r.Describe(), r.Score))
+		}
+		if len(reasons) > 0 {
+			fmt.Printf("    推荐理由：%s\n", strings.Join(reasons, "、"))
+		}
+	}
+	fmt.Printf("=============================\n")
+}
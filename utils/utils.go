@@ -64,8 +64,8 @@ func PrintUserInfo(info *trakt.TraktUserInfo) {
 	fmt.Printf("=============================\n")
 }
 
-// PrintWatchHistory 格式化打印观看记录
-func PrintWatchHistory(history []trakt.TraktWatchHistoryItem) {
+// PrintWatchHistory 格式化打印观看记录（ratings 为用户评分索引，可为nil）
+func PrintWatchHistory(history []trakt.TraktWatchHistoryItem, ratings trakt.RatingIndex) {
 	fmt.Printf("\n===== 最近 %d 条观看记录 =====\n", len(history))
 	for i, item := range history {
 		fmt.Printf("\n【第 %d 条】\n", i+1)
@@ -86,6 +86,9 @@ func PrintWatchHistory(history []trakt.TraktWatchHistoryItem) {
 			}
 			fmt.Printf("状态：%s\n", item.Show.Status)
 			fmt.Printf("评分：%.2f (%d票)\n", item.Show.Rating, item.Show.Votes)
+			if my := ratings.Get("show", item.Show.IDs.Trakt); my > 0 {
+				fmt.Printf("我的评分：%d/10\n", my)
+			}
 			if item.Show.Network != "" {
 				fmt.Printf("播出网络：%s\n", item.Show.Network)
 			}
@@ -109,30 +112,37 @@ func PrintWatchHistory(history []trakt.TraktWatchHistoryItem) {
 			fmt.Printf("IMDB ID：%s\n", item.Show.IDs.IMDB)
 			fmt.Printf("TMDb ID：%d\n", item.Show.IDs.TMDb)
 			
-			if item.Show.Episode != nil {
+			// /sync/history 中的单集信息位于顶层 episode 字段
+			if item.Episode != nil {
 				fmt.Printf("\n----- 单集信息 -----\n")
-				fmt.Printf("第 %d 季 第 %d 集\n", item.Show.Episode.Season, item.Show.Episode.Number)
-				fmt.Printf("集数标题：%s\n", item.Show.Episode.Title)
-				fmt.Printf("中文集名：%s\n", item.Show.Episode.OriginalTitle)
-				fmt.Printf("集数编码：S%02dE%02d\n", item.Show.Episode.Season, item.Show.Episode.Number)
-				if item.Show.Episode.Overview != "" {
-					fmt.Printf("集数简介：%s\n", item.Show.Episode.Overview)
+				fmt.Printf("第 %d 季 第 %d 集\n", item.Episode.Season, item.Episode.Number)
+				fmt.Printf("集数标题：%s\n", item.Episode.Title)
+				fmt.Printf("集数编码：S%02dE%02d\n", item.Episode.Season, item.Episode.Number)
+				if item.Episode.Overview != "" {
+					fmt.Printf("集数简介：%s\n", item.Episode.Overview)
+				}
+				fmt.Printf("集数评分：%.2f (%d票)\n", item.Episode.Rating, item.Episode.Votes)
+				if my := ratings.Get("episode", item.Episode.IDs.Trakt); my > 0 {
+					fmt.Printf("我的单集评分：%d/10\n", my)
 				}
-				fmt.Printf("集数评分：%.2f (%d票)\n", item.Show.Episode.Rating, item.Show.Episode.Votes)
-				if item.Show.Episode.Runtime > 0 {
-					fmt.Printf("集数时长：%d分钟\n", item.Show.Episode.Runtime)
+				if item.Episode.Runtime > 0 {
+					fmt.Printf("集数时长：%d分钟\n", item.Episode.Runtime)
 				}
-				if item.Show.Episode.FirstAired != "" {
-					fmt.Printf("首播日期：%s\n", item.Show.Episode.FirstAired)
+				if item.Episode.FirstAired != nil {
+					fmt.Printf("首播日期：%s\n", item.Episode.FirstAired.Local().Format("2006-01-02"))
 				}
-				fmt.Printf("单集Trakt ID：%.0f\n", item.Show.Episode.IDs.Trakt)
-				fmt.Printf("单集IMDB ID：%s\n", item.Show.Episode.IDs.IMDB)
-				fmt.Printf("单集TMDb ID：%.0f\n", item.Show.Episode.IDs.TMDb)
+				fmt.Printf("单集Trakt ID：%d\n", item.Episode.IDs.Trakt)
+				fmt.Printf("单集IMDB ID：%s\n", item.Episode.IDs.IMDB)
+				fmt.Printf("单集TMDb ID：%d\n", item.Episode.IDs.TMDb)
 			}
 			fmt.Printf("====================\n")
 		} else if item.Type == "movie" && item.Movie != nil {
 			fmt.Printf("\n===== 电影信息 =====\n")
-			fmt.Printf("标题：\n") // 暂时不处理
+			fmt.Printf("标题：%s (%d)\n", item.Movie.Title, item.Movie.Year)
+			fmt.Printf("评分：%.2f (%d票)\n", item.Movie.Rating, item.Movie.Votes)
+			if my := ratings.Get("movie", item.Movie.IDs.Trakt); my > 0 {
+				fmt.Printf("我的评分：%d/10\n", my)
+			}
 			fmt.Printf("====================\n")
 		}
 	}
@@ -146,8 +156,12 @@ func getTypeChineseName(itemType string) string {
 		return "电影"
 	case "show":
 		return "剧集"
+	case "season":
+		return "季"
 	case "episode":
 		return "单集"
+	case "person":
+		return "人物"
 	default:
 		return itemType
 	}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"traktshow/release"
+	"traktshow/scrobbler"
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runWatch 监听本地播放器并自动上报播放状态（mpv）
+func runWatch(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法：watch mpv [参数]")
+	}
+	switch args[0] {
+	case "mpv":
+		return watchMPV(args[1:])
+	default:
+		return fmt.Errorf("未知的播放器：%s", args[0])
+	}
+}
+
+// watchMPV 连接 mpv 的 JSON IPC socket 并上报播放状态（mpv 退出后等待重新连接）
+func watchMPV(args []string) error {
+	fs := flag.NewFlagSet("watch mpv", flag.ExitOnError)
+	socket := fs.String("socket", "/tmp/mpvsocket", "mpv 的 IPC socket 路径（mpv --input-ipc-server=<路径>）")
+	retry := fs.Duration("retry", 5*time.Second, "连接失败或 mpv 退出后的重试间隔（为0时不重试）")
+	minConfidence := fs.Float64("min-confidence", 0.6, "文件名匹配的最低置信度（0-1）")
+	fs.Parse(args)
+
+	watcher := &scrobbler.MPVWatcher{
+		Scrobbler: &scrobbler.TraktScrobbler{LoadToken: utils.LoadToken},
+		Identify:  newFileIdentifier(*minConfidence),
+	}
+	for {
+		conn, err := scrobbler.DialMPV(*socket)
+		if err == nil {
+			log.Printf("🎬 已连接 mpv：%s", *socket)
+			err = watcher.Watch(conn)
+			conn.Close()
+			log.Printf("mpv 连接已断开")
+		}
+		if *retry <= 0 {
+			return err
+		}
+		if err != nil {
+			log.Printf("⚠️  %v，%s 后重试", err, *retry)
+		}
+		time.Sleep(*retry)
+	}
+}
+
+// newFileIdentifier 创建按文件名识别条目的函数（置信度低于 minConfidence 的匹配不上报）
+func newFileIdentifier(minConfidence float64) func(path string) (trakt.ScrobbleRequest, error) {
+	// 近期观看记录用于优先匹配正在追的剧集，获取失败时仅依赖搜索
+	history, err := trakt.GetHistoryRange(accessToken, "", time.Now().AddDate(0, -3, 0), time.Time{})
+	if err != nil {
+		log.Printf("⚠️  获取近期观看记录失败：%v", err)
+	}
+	matcher := release.NewMatcher(accessToken, history)
+
+	return func(path string) (trakt.ScrobbleRequest, error) {
+		match, err := matcher.Match(release.Parse(path))
+		if err != nil {
+			return trakt.ScrobbleRequest{}, err
+		}
+		if match.Confidence < minConfidence {
+			return trakt.ScrobbleRequest{}, fmt.Errorf("匹配置信度过低：%s（%.0f%%）", match.Describe(), match.Confidence*100)
+		}
+		log.Printf("识别为：%s（置信度 %.0f%%）", match.Describe(), match.Confidence*100)
+		return match.Request(), nil
+	}
+}
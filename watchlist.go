@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"traktshow/trakt"
+	"traktshow/utils"
+)
+
+// runWatchlist 观看清单管理（list/add/remove/reorder）
+func runWatchlist(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("watchlist list", flag.ExitOnError)
+		itemType := fs.String("type", "", "条目类型：movies、shows、seasons、episodes（默认全部）")
+		sortBy := fs.String("sort", "rank", "排序方式：rank、added、title、released")
+		fs.Parse(args[1:])
+
+		items, err := trakt.GetWatchlist(accessToken, *itemType, *sortBy)
+		if err != nil {
+			return err
+		}
+		utils.PrintListItems("观看清单", items)
+		return nil
+
+	case "add", "remove":
+		fs := flag.NewFlagSet("watchlist "+args[0], flag.ExitOnError)
+		target := addItemFlags(fs)
+		fs.Parse(args[1:])
+
+		items, err := target.resolve(fs.Args())
+		if err != nil {
+			return err
+		}
+		var result *trakt.SyncResult
+		if args[0] == "add" {
+			result, err = trakt.AddToWatchlist(accessToken, items)
+		} else {
+			result, err = trakt.RemoveFromWatchlist(accessToken, items)
+		}
+		if err != nil {
+			return err
+		}
+		utils.PrintSyncResult(result)
+		return nil
+
+	case "reorder":
+		if len(args) != 3 {
+			return fmt.Errorf("用法：watchlist reorder <条目ID> <新排名>")
+		}
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("无效的条目ID：%s", args[1])
+		}
+		position, err := strconv.Atoi(args[2])
+		if err != nil || position < 1 {
+			return fmt.Errorf("无效的排名：%s", args[2])
+		}
+		return reorderWatchlist(id, position)
+	}
+	return fmt.Errorf("未知的子命令：%s（可用：list、add、remove、reorder）", args[0])
+}
+
+// reorderWatchlist 将观看清单中的条目移动到指定排名
+func reorderWatchlist(id int64, position int) error {
+	items, err := trakt.GetWatchlist(accessToken, "", "rank")
+	if err != nil {
+		return err
+	}
+
+	rank, err := moveRank(items, id, position)
+	if err != nil {
+		return err
+	}
+	result, err := trakt.ReorderWatchlist(accessToken, rank)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已更新 %d 个条目的排名\n", result.Updated)
+	return nil
+}
+
+// moveRank 将指定条目移动到新排名（从1开始），返回全部条目ID的新顺序
+func moveRank(items []trakt.TraktListItem, id int64, position int) ([]int64, error) {
+	var rank []int64
+	found := false
+	for _, item := range items {
+		if item.ID == id {
+			found = true
+			continue
+		}
+		rank = append(rank, item.ID)
+	}
+	if !found {
+		return nil, fmt.Errorf("列表中不存在条目ID：%d", id)
+	}
+
+	if position > len(rank)+1 {
+		position = len(rank) + 1
+	}
+	rank = append(rank[:position-1], append([]int64{id}, rank[position-1:]...)...)
+	return rank, nil
+}